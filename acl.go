@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"codeberg.org/emersion/go-scfg"
+
+	"git.sr.ht/~xenrox/hut/termfmt"
+)
+
+// aclEntry is an access-control list entry, independent of the sr.ht service
+// it belongs to. Git and hg use a single access mode as permission, other
+// services a set of permissions.
+type aclEntry struct {
+	ID          int32 // zero if the entry doesn't exist
+	Entity      string
+	Permissions []string
+}
+
+// aclResource is the list of desired ACL entries for a resource, as described
+// in an ACL file. Name is empty for entries which apply to the resource
+// selected on the command line.
+type aclResource struct {
+	Name    string
+	Entries []aclEntry
+}
+
+// aclPermissionsFunc validates and normalizes the permissions of an ACL
+// entry.
+type aclPermissionsFunc func(permissions []string) ([]string, error)
+
+// readACLFile reads an ACL file. Entries are either top-level "acl"
+// directives, or "acl" directives inside blocks named after kind.
+func readACLFile(filename, kind string, normalize aclPermissionsFunc) ([]aclResource, error) {
+	var r io.Reader
+	if filename == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	block, err := scfg.Read(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ACL file: %v", err)
+	}
+
+	var resources []aclResource
+	top := -1 // index of the resource holding top-level entries
+	for _, d := range block {
+		switch d.Name {
+		case "acl":
+			if top < 0 {
+				top = len(resources)
+				resources = append(resources, aclResource{})
+			}
+			entry, err := parseACLDirective(d, normalize)
+			if err != nil {
+				return nil, err
+			}
+			resources[top].Entries = append(resources[top].Entries, *entry)
+		case kind:
+			if len(d.Params) != 1 {
+				return nil, fmt.Errorf("directive %q: expected exactly one name", kind)
+			}
+			resource := aclResource{Name: d.Params[0]}
+			for _, child := range d.Children {
+				if child.Name != "acl" {
+					return nil, fmt.Errorf("%v %q: unknown directive %q", kind, resource.Name, child.Name)
+				}
+				entry, err := parseACLDirective(child, normalize)
+				if err != nil {
+					return nil, fmt.Errorf("%v %q: %v", kind, resource.Name, err)
+				}
+				resource.Entries = append(resource.Entries, *entry)
+			}
+			resources = append(resources, resource)
+		default:
+			return nil, fmt.Errorf("unknown directive %q", d.Name)
+		}
+	}
+
+	for _, resource := range resources {
+		seen := make(map[string]bool)
+		for _, entry := range resource.Entries {
+			if seen[entry.Entity] {
+				return nil, fmt.Errorf("duplicate ACL entry for %q", entry.Entity)
+			}
+			seen[entry.Entity] = true
+		}
+	}
+
+	return resources, nil
+}
+
+func parseACLDirective(d *scfg.Directive, normalize aclPermissionsFunc) (*aclEntry, error) {
+	if len(d.Params) == 0 {
+		return nil, fmt.Errorf("directive %q: missing user", d.Name)
+	}
+
+	entity := d.Params[0]
+	if strings.IndexAny(entity, ownerPrefixes) != 0 {
+		return nil, fmt.Errorf("user %q must be in canonical form", entity)
+	}
+
+	permissions, err := normalize(d.Params[1:])
+	if err != nil {
+		return nil, fmt.Errorf("user %q: %v", entity, err)
+	}
+
+	return &aclEntry{Entity: entity, Permissions: permissions}, nil
+}
+
+// aclModePermissions returns an aclPermissionsFunc accepting exactly one
+// access mode.
+func aclModePermissions(parse func(s string) (string, error)) aclPermissionsFunc {
+	return func(permissions []string) ([]string, error) {
+		if len(permissions) != 1 {
+			return nil, fmt.Errorf("expected exactly one access mode, got %d", len(permissions))
+		}
+		mode, err := parse(permissions[0])
+		if err != nil {
+			return nil, err
+		}
+		return []string{mode}, nil
+	}
+}
+
+// aclSetPermissions returns an aclPermissionsFunc accepting any subset of
+// the provided permissions. "none" can be used for an empty set.
+func aclSetPermissions(all ...string) aclPermissionsFunc {
+	return func(permissions []string) ([]string, error) {
+		set := make(map[string]bool)
+		for _, p := range permissions {
+			p = strings.ToLower(p)
+			if p == "none" {
+				continue
+			}
+			if !sliceContains(all, p) {
+				return nil, fmt.Errorf("invalid permission %q (valid: %v)", p, strings.Join(all, ", "))
+			}
+			set[p] = true
+		}
+
+		normalized := []string{}
+		for _, p := range all {
+			if set[p] {
+				normalized = append(normalized, p)
+			}
+		}
+		return normalized, nil
+	}
+}
+
+type aclChangeKind int
+
+const (
+	aclChangeAdd aclChangeKind = iota
+	aclChangeUpdate
+	aclChangeDelete
+)
+
+type aclChange struct {
+	Kind  aclChangeKind
+	Entry aclEntry // the desired entry, or the deleted one
+	Old   []string // previous permissions of updated entries
+}
+
+// diffACL computes the changes required to turn current into desired. Entries
+// which are not listed in desired are only removed if prune is set.
+func diffACL(current, desired []aclEntry, prune bool) []aclChange {
+	byEntity := make(map[string]aclEntry)
+	for _, entry := range current {
+		byEntity[entry.Entity] = entry
+	}
+
+	var changes []aclChange
+	listed := make(map[string]bool)
+	for _, entry := range desired {
+		listed[entry.Entity] = true
+		cur, ok := byEntity[entry.Entity]
+		if !ok {
+			changes = append(changes, aclChange{Kind: aclChangeAdd, Entry: entry})
+		} else if !equalPermissions(cur.Permissions, entry.Permissions) {
+			entry.ID = cur.ID
+			changes = append(changes, aclChange{Kind: aclChangeUpdate, Entry: entry, Old: cur.Permissions})
+		}
+	}
+
+	if prune {
+		for _, entry := range current {
+			if !listed[entry.Entity] {
+				changes = append(changes, aclChange{Kind: aclChangeDelete, Entry: entry})
+			}
+		}
+	}
+
+	return changes
+}
+
+func equalPermissions(a, b []string) bool {
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
+
+func formatPermissions(permissions []string) string {
+	if len(permissions) == 0 {
+		return "none"
+	}
+	return strings.Join(permissions, ",")
+}
+
+func printACLPlan(w io.Writer, name string, changes []aclChange) {
+	fmt.Fprintln(w, termfmt.Bold.String(name))
+	if len(changes) == 0 {
+		fmt.Fprintln(w, termfmt.Dim.String("  no changes"))
+		return
+	}
+
+	for _, change := range changes {
+		entry := change.Entry
+		switch change.Kind {
+		case aclChangeAdd:
+			fmt.Fprintln(w, termfmt.Green.Sprintf("  + %s %s", entry.Entity, formatPermissions(entry.Permissions)))
+		case aclChangeUpdate:
+			fmt.Fprintln(w, termfmt.Yellow.Sprintf("  ~ %s %s -> %s", entry.Entity,
+				formatPermissions(change.Old), formatPermissions(entry.Permissions)))
+		case aclChangeDelete:
+			fmt.Fprintln(w, termfmt.Red.Sprintf("  - %s %s", entry.Entity, formatPermissions(entry.Permissions)))
+		}
+	}
+}
+
+// aclBackend implements the ACL operations of a resource.
+type aclBackend struct {
+	List   func(ctx context.Context) ([]aclEntry, error)
+	Update func(ctx context.Context, entity string, permissions []string) error
+	Delete func(ctx context.Context, id int32) error
+}
+
+// applyACL prints the plan to turn the ACL of a resource into the desired
+// entries, and executes it unless dryRun is set.
+func applyACL(ctx context.Context, name string, backend *aclBackend, desired []aclEntry, prune, dryRun bool) error {
+	current, err := backend.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := diffACL(current, desired, prune)
+	printACLPlan(os.Stdout, name, changes)
+	if dryRun {
+		return nil
+	}
+
+	for _, change := range changes {
+		entry := change.Entry
+		switch change.Kind {
+		case aclChangeAdd, aclChangeUpdate:
+			err = backend.Update(ctx, entry.Entity, entry.Permissions)
+		case aclChangeDelete:
+			err = backend.Delete(ctx, entry.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update ACL entry for %q: %v", entry.Entity, err)
+		}
+	}
+
+	if len(changes) > 0 {
+		log.Printf("Applied %d ACL changes to %s\n", len(changes), name)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadACLFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		resources []aclResource
+		err       bool
+	}{
+		{
+			name:    "top-level",
+			content: "acl ~alice browse reply\nacl ~bob none\n",
+			resources: []aclResource{{Entries: []aclEntry{
+				{Entity: "~alice", Permissions: []string{"browse", "reply"}},
+				{Entity: "~bob", Permissions: []string{}},
+			}}},
+		},
+		{
+			name:    "blocks",
+			content: "list foo {\n\tacl ~alice POST\n}\nacl ~bob browse\n",
+			resources: []aclResource{
+				{Name: "foo", Entries: []aclEntry{{Entity: "~alice", Permissions: []string{"post"}}}},
+				{Entries: []aclEntry{{Entity: "~bob", Permissions: []string{"browse"}}}},
+			},
+		},
+		{name: "unknown directive", content: "repo foo {\n}\n", err: true},
+		{name: "unknown child", content: "list foo {\n\tuser ~alice\n}\n", err: true},
+		{name: "missing name", content: "list {\n}\n", err: true},
+		{name: "missing user", content: "acl\n", err: true},
+		{name: "non-canonical user", content: "acl alice browse\n", err: true},
+		{name: "invalid permission", content: "acl ~alice admin\n", err: true},
+		{name: "duplicate", content: "acl ~alice browse\nacl ~alice reply\n", err: true},
+	}
+
+	normalize := aclSetPermissions("browse", "reply", "post")
+	for _, test := range tests {
+		filename := filepath.Join(t.TempDir(), "acl")
+		if err := os.WriteFile(filename, []byte(test.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		resources, err := readACLFile(filename, "list", normalize)
+		if test.err {
+			if err == nil {
+				t.Errorf("readACLFile(%q): expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("readACLFile(%q): %v", test.name, err)
+		} else if !reflect.DeepEqual(resources, test.resources) {
+			t.Errorf("readACLFile(%q): expected %+v, got %+v", test.name, test.resources, resources)
+		}
+	}
+}
+
+func TestDiffACL(t *testing.T) {
+	current := []aclEntry{
+		{ID: 1, Entity: "~alice", Permissions: []string{"browse", "reply"}},
+		{ID: 2, Entity: "~bob", Permissions: []string{"browse"}},
+	}
+
+	tests := []struct {
+		name    string
+		desired []aclEntry
+		prune   bool
+		changes []aclChange
+	}{
+		{
+			name: "unchanged",
+			desired: []aclEntry{
+				{Entity: "~alice", Permissions: []string{"reply", "browse"}},
+			},
+		},
+		{
+			name: "add and update",
+			desired: []aclEntry{
+				{Entity: "~alice", Permissions: []string{"browse"}},
+				{Entity: "~carol", Permissions: []string{"post"}},
+			},
+			changes: []aclChange{
+				{Kind: aclChangeUpdate, Entry: aclEntry{ID: 1, Entity: "~alice", Permissions: []string{"browse"}}, Old: []string{"browse", "reply"}},
+				{Kind: aclChangeAdd, Entry: aclEntry{Entity: "~carol", Permissions: []string{"post"}}},
+			},
+		},
+		{
+			name: "prune",
+			desired: []aclEntry{
+				{Entity: "~alice", Permissions: []string{"browse", "reply"}},
+			},
+			prune: true,
+			changes: []aclChange{
+				{Kind: aclChangeDelete, Entry: current[1]},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		changes := diffACL(current, test.desired, test.prune)
+		if !reflect.DeepEqual(changes, test.changes) {
+			t.Errorf("diffACL(%q): expected %+v, got %+v", test.name, test.changes, changes)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	"github.com/dustin/go-humanize"
 	"github.com/juju/ansiterm/tabwriter"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"git.sr.ht/~xenrox/hut/srht/buildssrht"
 	"git.sr.ht/~xenrox/hut/termfmt"
@@ -33,6 +35,7 @@ func newBuildsCommand() *cobra.Command {
 	cmd.AddCommand(newBuildsCancelCommand())
 	cmd.AddCommand(newBuildsShowCommand())
 	cmd.AddCommand(newBuildsListCommand())
+	cmd.AddCommand(newBuildsLocalCommand())
 	cmd.AddCommand(newBuildsUpdateCommand())
 	cmd.AddCommand(newBuildsSecretCommand())
 	cmd.AddCommand(newBuildsSSHCommand())
@@ -454,6 +457,50 @@ func newBuildsListCommand() *cobra.Command {
 	return cmd
 }
 
+func newBuildsLocalCommand() *cobra.Command {
+	var tasks []string
+	run := func(cmd *cobra.Command, args []string) {
+		var (
+			b   []byte
+			err error
+		)
+		if args[0] == "-" {
+			b, err = io.ReadAll(os.Stdin)
+		} else {
+			b, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			log.Fatalf("failed to read manifest from %q: %v", args[0], err)
+		}
+
+		manifest, err := parseBuildManifest(b)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, name := range tasks {
+			if !manifest.hasTask(name) {
+				log.Fatalf("no such task %q in manifest", name)
+			}
+		}
+
+		if err := writeLocalBuildScript(os.Stdout, manifest, tasks); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "local <manifest>",
+		Short:             "Render a build manifest into a local shell script",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.FixedCompletions([]string{"yml", "yaml"}, cobra.ShellCompDirectiveFilterFileExt),
+		Run:               run,
+	}
+	cmd.Flags().StringSliceVarP(&tasks, "task", "t", nil, "tasks to run")
+	cmd.RegisterFlagCompletionFunc("task", cobra.NoFileCompletions)
+	return cmd
+}
+
 func newBuildsUpdateCommand() *cobra.Command {
 	var visibility string
 	run := func(cmd *cobra.Command, args []string) {
@@ -585,16 +632,16 @@ func newBuildsUserWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newBuildsUserWebhookCreateCommand())
 	cmd.AddCommand(newBuildsUserWebhookListCommand())
 	cmd.AddCommand(newBuildsUserWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadBuildsUserWebhookDeliveries, loadBuildsUserWebhookSample, nil)
 	return cmd
 }
 
 func newBuildsUserWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		c := createClient("builds", cmd)
 
 		var config buildssrht.UserWebhookInput
 		config.Url = url
@@ -605,6 +652,19 @@ func newBuildsUserWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("builds", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("builds", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClient("builds", cmd)
 
 		webhook, err := buildssrht.CreateUserWebhook(c.Client, ctx, config)
 		if err != nil {
@@ -630,7 +690,10 @@ func newBuildsUserWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -703,6 +766,50 @@ func newBuildsUserWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadBuildsUserWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	c := createClient("builds", cmd)
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		webhook, err := buildssrht.UserWebhookDeliveries(c.Client, ctx, id, (*buildssrht.Cursor)(cursor))
+		if err != nil {
+			return nil, nil, err
+		} else if webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertBuildsWebhookDeliveries(webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadBuildsUserWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	c := createClient("builds", cmd)
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		webhook, err := buildssrht.UserWebhookSample(c.Client, ctx, id, buildssrht.WebhookEvent(event))
+		if err != nil {
+			return "", err
+		} else if webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return webhook.Sample, nil
+	}
+}
+
+func convertBuildsWebhookDeliveries(cursor *buildssrht.WebhookDeliveryCursor) ([]webhookDelivery, *string) {
+	var deliveries []webhookDelivery
+	for _, delivery := range cursor.Results {
+		deliveries = append(deliveries, webhookDelivery{
+			UUID:            delivery.Uuid,
+			Date:            delivery.Date.Time,
+			Event:           string(delivery.Event),
+			RequestBody:     delivery.RequestBody,
+			ResponseBody:    delivery.ResponseBody,
+			ResponseHeaders: delivery.ResponseHeaders,
+			ResponseStatus:  delivery.ResponseStatus,
+		})
+	}
+	return deliveries, (*string)(cursor.Cursor)
+}
+
 func printJob(w io.Writer, job *buildssrht.Job) {
 	fmt.Fprint(w, termfmt.DarkYellow.Sprintf("#%d", job.Id))
 	if tagString := formatJobTags(job); tagString != "" {
@@ -1050,6 +1157,208 @@ func followJobShow(ctx context.Context, c *Client, id int32) (*buildssrht.Job, e
 	}
 }
 
+type buildManifest struct {
+	Image       string              `yaml:"image"`
+	Packages    []string            `yaml:"packages"`
+	Sources     []string            `yaml:"sources"`
+	Environment map[string]any      `yaml:"environment"`
+	Secrets     []string            `yaml:"secrets"`
+	Tasks       []map[string]string `yaml:"tasks"`
+}
+
+type buildTask struct {
+	Name   string
+	Script string
+}
+
+var buildEnvNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func parseBuildManifest(b []byte) (*buildManifest, error) {
+	var manifest buildManifest
+	if err := yaml.Unmarshal(b, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %v", err)
+	}
+
+	for _, task := range manifest.Tasks {
+		if len(task) != 1 {
+			return nil, errors.New("invalid manifest: each task must have exactly one name")
+		}
+		for name := range task {
+			if name == "" || strings.Trim(name, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-") != "" {
+				return nil, fmt.Errorf("invalid manifest: invalid task name %q", name)
+			}
+		}
+	}
+
+	for key := range manifest.Environment {
+		if !buildEnvNameRegexp.MatchString(key) {
+			return nil, fmt.Errorf("invalid manifest: invalid environment variable name %q", key)
+		}
+	}
+
+	return &manifest, nil
+}
+
+func (manifest *buildManifest) tasks() []buildTask {
+	var tasks []buildTask
+	for _, task := range manifest.Tasks {
+		for name, script := range task {
+			tasks = append(tasks, buildTask{Name: name, Script: script})
+		}
+	}
+	return tasks
+}
+
+func (manifest *buildManifest) hasTask(name string) bool {
+	for _, task := range manifest.tasks() {
+		if task.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+type buildSource struct {
+	SCM string
+	URL string
+	Ref string
+	Dir string
+}
+
+// parseBuildSource parses a manifest source the same way the builds.sr.ht
+// runner does: an optional "scm+" prefix, the URL and an optional "#ref".
+func parseBuildSource(s string) buildSource {
+	source := buildSource{SCM: "git", URL: s}
+
+	if i := strings.LastIndex(source.URL, "#"); i >= 0 {
+		source.Ref = source.URL[i+1:]
+		source.URL = source.URL[:i]
+	}
+
+	if i := strings.Index(source.URL, "://"); i >= 0 {
+		if scm, rest, ok := strings.Cut(source.URL[:i], "+"); ok {
+			source.SCM = scm
+			source.URL = rest + source.URL[i:]
+		}
+	}
+
+	dir := strings.TrimRight(source.URL, "/")
+	dir = dir[strings.LastIndexAny(dir, "/:")+1:]
+	source.Dir = strings.TrimSuffix(dir, ".git")
+	return source
+}
+
+// writeLocalBuildScript renders a manifest into a standalone bash script. Each
+// task becomes a function, and the tasks to run can be selected with
+// arguments to the script. If only is not empty, the script runs only these
+// tasks by default. Like builds.sr.ht loads ~/.buildenv, each task starts by
+// loading the manifest environment, so that arrays are available too.
+func writeLocalBuildScript(w io.Writer, manifest *buildManifest, only []string) error {
+	var sb strings.Builder
+
+	sb.WriteString("#!/usr/bin/env bash\n")
+	sb.WriteString("# Generated by hut from a builds.sr.ht manifest.\n")
+	if manifest.Image != "" {
+		fmt.Fprintf(&sb, "# image: %s\n", manifest.Image)
+	}
+	if len(manifest.Packages) > 0 {
+		fmt.Fprintf(&sb, "# packages: %s\n", strings.Join(manifest.Packages, " "))
+	}
+	if len(manifest.Secrets) > 0 {
+		fmt.Fprintf(&sb, "# secrets (not available locally): %s\n", strings.Join(manifest.Secrets, " "))
+	}
+	sb.WriteString("set -e\n\n")
+	sb.WriteString("BUILD_ROOT=\"${BUILD_ROOT:-$HOME}\"\n")
+
+	hasEnv := len(manifest.Environment) > 0
+	if hasEnv {
+		sb.WriteString("\nbuildenv() {\n")
+		keys := make([]string, 0, len(manifest.Environment))
+		for key := range manifest.Environment {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			switch v := manifest.Environment[key].(type) {
+			case []any:
+				var values []string
+				for _, value := range v {
+					values = append(values, shellQuote(fmt.Sprint(value)))
+				}
+				fmt.Fprintf(&sb, "\tdeclare -ga %s=(%s)\n", key, strings.Join(values, " "))
+			default:
+				fmt.Fprintf(&sb, "\texport %s=%s\n", key, shellQuote(fmt.Sprint(v)))
+			}
+		}
+		sb.WriteString("}\n")
+	}
+
+	sb.WriteString("\nclone_sources() {\n")
+	sb.WriteString("\tcd \"$BUILD_ROOT\"\n")
+	for _, s := range manifest.Sources {
+		source := parseBuildSource(s)
+		dir := shellQuote(source.Dir)
+
+		fmt.Fprintf(&sb, "\tif [ ! -e %s ]; then\n", dir)
+		switch source.SCM {
+		case "git":
+			fmt.Fprintf(&sb, "\t\tgit clone %s %s\n", shellQuote(source.URL), dir)
+			if source.Ref != "" {
+				fmt.Fprintf(&sb, "\t\tgit -C %s checkout -q %s\n", dir, shellQuote(source.Ref))
+			}
+			fmt.Fprintf(&sb, "\t\tgit -C %s submodule update --init\n", dir)
+		case "hg":
+			fmt.Fprintf(&sb, "\t\thg clone %s %s\n", shellQuote(source.URL), dir)
+			if source.Ref != "" {
+				fmt.Fprintf(&sb, "\t\thg -R %s update %s\n", dir, shellQuote(source.Ref))
+			}
+		default:
+			return fmt.Errorf("unsupported source control system %q in source %q", source.SCM, s)
+		}
+		sb.WriteString("\tfi\n")
+	}
+	sb.WriteString("\t:\n}\n")
+
+	tasks := manifest.tasks()
+	for _, task := range tasks {
+		fmt.Fprintf(&sb, "\ntask_%s() (\n", task.Name)
+		sb.WriteString("\tcd \"$BUILD_ROOT\"\n")
+		if hasEnv {
+			sb.WriteString("\tbuildenv\n")
+		}
+		sb.WriteString("\tset -xe\n")
+		sb.WriteString(strings.TrimRight(task.Script, "\n"))
+		sb.WriteString("\n)\n")
+	}
+
+	if len(only) == 0 {
+		for _, task := range tasks {
+			only = append(only, task.Name)
+		}
+	}
+
+	sb.WriteString("\nif [ $# -eq 0 ]; then\n")
+	fmt.Fprintf(&sb, "\tset -- %s\n", strings.Join(only, " "))
+	sb.WriteString("fi\n\n")
+	sb.WriteString("clone_sources\n")
+	sb.WriteString("for task in \"$@\"; do\n")
+	sb.WriteString("\tcase \"$task\" in\n")
+	for _, task := range tasks {
+		fmt.Fprintf(&sb, "\t%s) task_%s ;;\n", task.Name, task.Name)
+	}
+	sb.WriteString("\t*) echo \"unknown task: $task\" >&2; exit 1 ;;\n")
+	sb.WriteString("\tesac\n")
+	sb.WriteString("done\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 var completeJobStatus = cobra.FixedCompletions([]string{
 	"pending",
 	"queued",
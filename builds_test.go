@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBuildManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		err      bool
+	}{
+		{"valid", "image: alpine/edge\nenvironment:\n  FOO_1: bar\ntasks:\n  - build-it: make\n", false},
+		{"invalid yaml", "tasks: [", true},
+		{"several names", "tasks:\n  - a: true\n    b: true\n", true},
+		{"invalid task name", "tasks:\n  - a b: true\n", true},
+		{"invalid environment name", "environment:\n  FOO;rm -rf ~: bar\n", true},
+		{"environment name starting with a digit", "environment:\n  1FOO: bar\n", true},
+	}
+
+	for _, test := range tests {
+		_, err := parseBuildManifest([]byte(test.manifest))
+		if test.err && err == nil {
+			t.Errorf("parseBuildManifest(%s): expected an error", test.name)
+		} else if !test.err && err != nil {
+			t.Errorf("parseBuildManifest(%s): %v", test.name, err)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", "''"},
+		{"foo bar", "'foo bar'"},
+		{"$HOME", "'$HOME'"},
+		{"it's", `'it'\''s'`},
+	}
+
+	for _, test := range tests {
+		got := shellQuote(test.s)
+		if got != test.want {
+			t.Errorf("shellQuote(%q): expected %q, got %q", test.s, test.want, got)
+		}
+	}
+}
+
+func TestWriteLocalBuildScriptEnvironment(t *testing.T) {
+	manifest, err := parseBuildManifest([]byte("environment:\n  FOO: it's\n  LIST: [a, b]\ntasks:\n  - build: make\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+	if err := writeLocalBuildScript(&sb, manifest, nil); err != nil {
+		t.Fatal(err)
+	}
+	script := sb.String()
+
+	for _, want := range []string{
+		"buildenv() {\n\texport FOO='it'\\''s'\n\tdeclare -ga LIST=('a' 'b')\n}\n",
+		"task_build() (\n\tcd \"$BUILD_ROOT\"\n\tbuildenv\n",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("writeLocalBuildScript: expected script to contain %q, got:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, ".buildenv") {
+		t.Errorf("writeLocalBuildScript: script reads ~/.buildenv:\n%s", script)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+
+	"git.sr.ht/~xenrox/hut/termfmt"
+)
+
+// bulkRepo is a repository selected by a bulk operation.
+type bulkRepo struct {
+	ID   int32
+	Name string
+}
+
+// matchRepoName reports whether a repository name matches a bulk operation
+// glob pattern. An empty pattern matches all repositories.
+func matchRepoName(pattern, name string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
+
+func checkRepoPattern(pattern string) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		log.Fatalf("invalid pattern %q: %v", pattern, err)
+	}
+}
+
+// runRepoBulk lists the repositories affected by a bulk operation, asks for a
+// single confirmation and runs fn on each repository. A summary is printed
+// at the end, and hut exits with an error if fn failed for any repository.
+func runRepoBulk(verb string, repos []bulkRepo, autoConfirm bool, fn func(repo bulkRepo) error) {
+	if len(repos) == 0 {
+		log.Fatal("no matching repositories")
+	}
+
+	fmt.Printf("%d repositories selected:\n", len(repos))
+	for _, repo := range repos {
+		fmt.Printf("  %s\n", repo.Name)
+	}
+
+	if !autoConfirm && !getConfirmation(fmt.Sprintf("Do you really want to %s %d repositories", verb, len(repos))) {
+		log.Println("Aborted")
+		return
+	}
+
+	errs := make([]error, len(repos))
+	var failed int
+	for i, repo := range repos {
+		log.Printf("[%d/%d] %s\n", i+1, len(repos), repo.Name)
+		errs[i] = fn(repo)
+		if errs[i] != nil {
+			failed++
+		}
+	}
+
+	fmt.Println()
+	for i, repo := range repos {
+		if errs[i] != nil {
+			fmt.Printf("%s %s: %v\n", termfmt.Red.String("✗"), repo.Name, errs[i])
+		} else {
+			fmt.Printf("%s %s\n", termfmt.Green.String("✔"), repo.Name)
+		}
+	}
+	fmt.Printf("%d succeeded, %d failed\n", len(repos)-failed, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,446 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"git.sr.ht/~xenrox/hut/srht/buildssrht"
+	"git.sr.ht/~xenrox/hut/srht/listssrht"
+)
+
+// ciToolPrefix is prepended to the details of the patchset tools managed by
+// hut, so that they can be told apart from other tools.
+const ciToolPrefix = "hut ci: "
+
+const ciApplyTask = "apply-patchset"
+
+func newListsPatchsetCICommand() *cobra.Command {
+	var daemon, follow bool
+	var base, manifest, visibility string
+	var interval time.Duration
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		buildsVisibility, err := buildssrht.ParseVisibility(visibility)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		repoDir, err := getCIRepoDir()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ci := &patchsetCI{
+			base:       base,
+			manifest:   manifest,
+			visibility: buildsVisibility,
+			repoDir:    repoDir,
+		}
+
+		if daemon {
+			var name, owner, instance string
+			if len(args) > 0 {
+				name, owner, instance = parseMailingListName(args[0])
+			} else {
+				name, owner, instance, err = getMailingListName(ctx, cmd)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+			ci.lists = createClientWithInstance("lists", cmd, instance)
+			ci.builds = createClientWithInstance("builds", cmd, instance)
+
+			if err := ci.watch(ctx, name, owner, interval); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		if len(args) == 0 {
+			log.Fatal("patchset ID required")
+		}
+		id, instance, err := parsePatchID(ctx, cmd, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		ci.lists = createClientWithInstance("lists", cmd, instance)
+		ci.builds = createClientWithInstance("builds", cmd, instance)
+
+		job, err := ci.start(ctx, id)
+		if err != nil {
+			log.Fatal(err)
+		} else if job == nil {
+			os.Exit(1)
+		}
+
+		if follow {
+			if _, err := followJob(ctx, ci.builds, job.jobID); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		for {
+			status, err := ci.poll(ctx, job)
+			if err != nil {
+				log.Fatal(err)
+			} else if jobStatusDone(status) {
+				if status != buildssrht.JobStatusSuccess {
+					os.Exit(1)
+				}
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				log.Fatal(ctx.Err())
+			case <-time.After(10 * time.Second):
+				// Continue looping
+			}
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "ci [ID]",
+		Short:             "Test a patchset with builds.sr.ht",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completePatchsetID,
+		Run:               run,
+	}
+	cmd.Flags().BoolVar(&daemon, "daemon", false, "watch the mailing list for new patchsets")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "follow build logs")
+	cmd.Flags().StringVar(&base, "base", "@{upstream}", "revision to apply patchsets on")
+	cmd.RegisterFlagCompletionFunc("base", cobra.NoFileCompletions)
+	cmd.Flags().StringVarP(&manifest, "manifest", "m", ".build.yml", "build manifest in the repository")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Minute, "polling interval in daemon mode")
+	cmd.RegisterFlagCompletionFunc("interval", cobra.NoFileCompletions)
+	cmd.Flags().StringVarP(&visibility, "visibility", "v", "unlisted", "builds visibility")
+	cmd.RegisterFlagCompletionFunc("visibility", completeVisibility)
+	cmd.MarkFlagsMutuallyExclusive("daemon", "follow")
+	return cmd
+}
+
+type patchsetCI struct {
+	lists, builds *Client
+	base          string
+	manifest      string
+	visibility    buildssrht.Visibility
+	repoDir       string // name of the directory the repository is cloned into by builds
+}
+
+// patchsetCIJob is a build job testing a patchset.
+type patchsetCIJob struct {
+	patchsetID int32
+	toolID     int32
+	jobID      int32
+	url        string
+}
+
+// getCIRepoDir returns the name of the directory builds.sr.ht clones the
+// current repository into, based on its "origin" remote.
+func getCIRepoDir() (string, error) {
+	b, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL of remote %q: %v", "origin", err)
+	}
+	return parseBuildSource(strings.TrimSpace(string(b))).Dir, nil
+}
+
+// start applies a patchset in a scratch worktree and submits a build for it.
+// A nil job is returned if the patchset could not be tested, in which case
+// a patchset tool reporting why has already been created. Secrets are never
+// exposed to patchset builds.
+func (ci *patchsetCI) start(ctx context.Context, id int32) (*patchsetCIJob, error) {
+	patchset, err := getPatchsetMbox(ctx, ci.lists, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var mbox bytes.Buffer
+	if err := fetchListsFile(ctx, ci.lists, string(patchset.Mbox), &mbox); err != nil {
+		return nil, fmt.Errorf("failed to fetch patchset: %v", err)
+	}
+
+	base, err := resolveCIBase(ci.base)
+	if err != nil {
+		return nil, err
+	}
+
+	// The patchset tool is only created once there is a result, so that
+	// patchsets which could not be tested because of an error are retried
+	manifest, err := applyCIPatchset(base, ci.manifest, mbox.Bytes())
+	if errors.Is(err, errPatchsetApply) {
+		log.Printf("Patchset %d does not apply on %.12s\n", id, base)
+		_, err := ci.createTool(ctx, id, listssrht.ToolIconFailed,
+			fmt.Sprintf("patchset does not apply on %.12s", base))
+		return nil, err
+	} else if errors.Is(err, os.ErrNotExist) {
+		log.Printf("Patchset %d has no build manifest %q\n", id, ci.manifest)
+		_, err := ci.createTool(ctx, id, listssrht.ToolIconCancelled, "no build manifest")
+		return nil, err
+	} else if err != nil {
+		return nil, err
+	}
+
+	manifest, err = rewriteCIManifest(manifest, ci.repoDir, base, mbox.Bytes())
+	if err != nil {
+		log.Printf("Patchset %d has an invalid build manifest: %v\n", id, err)
+		_, err := ci.createTool(ctx, id, listssrht.ToolIconFailed, "invalid build manifest")
+		return nil, err
+	}
+
+	tags := []string{patchset.List.Name, "patches", fmt.Sprint(id)}
+	note := fmt.Sprintf("Patchset #%d", id)
+	job, err := buildssrht.Submit(ci.builds.Client, ctx, string(manifest), tags, &note, &ci.visibility, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit build: %v", err)
+	}
+
+	url := fmt.Sprintf("%v/%v/job/%v", ci.builds.BaseURL, job.Owner.CanonicalName, job.Id)
+	log.Printf("Started build %v for patchset %d\n", url, id)
+	toolID, err := ci.createTool(ctx, id, listssrht.ToolIconPending, "build pending: "+url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &patchsetCIJob{
+		patchsetID: id,
+		toolID:     toolID,
+		jobID:      job.Id,
+		url:        url,
+	}, nil
+}
+
+// poll updates the patchset tool once the job is done.
+func (ci *patchsetCI) poll(ctx context.Context, job *patchsetCIJob) (buildssrht.JobStatus, error) {
+	status, err := buildssrht.Monitor(ci.builds.Client, ctx, job.jobID)
+	if err != nil {
+		return "", fmt.Errorf("failed to monitor job: %v", err)
+	} else if status == nil {
+		return "", fmt.Errorf("no such job %d", job.jobID)
+	}
+
+	var icon listssrht.ToolIcon
+	switch status.Status {
+	case buildssrht.JobStatusSuccess:
+		icon = listssrht.ToolIconSuccess
+	case buildssrht.JobStatusFailed, buildssrht.JobStatusTimeout:
+		icon = listssrht.ToolIconFailed
+	case buildssrht.JobStatusCancelled:
+		icon = listssrht.ToolIconCancelled
+	default:
+		return status.Status, nil
+	}
+
+	log.Printf("Build for patchset %d: %s\n", job.patchsetID, status.Status.TermString())
+	details := fmt.Sprintf("build %s: %s", strings.ToLower(string(status.Status)), job.url)
+	return status.Status, ci.updateTool(ctx, job.toolID, icon, details)
+}
+
+func (ci *patchsetCI) createTool(ctx context.Context, id int32, icon listssrht.ToolIcon, details string) (int32, error) {
+	tool, err := listssrht.CreateTool(ci.lists.Client, ctx, id, ciToolPrefix+details, icon)
+	if err != nil {
+		return 0, err
+	} else if tool == nil {
+		return 0, fmt.Errorf("failed to create tool for patchset %d", id)
+	}
+	return tool.Id, nil
+}
+
+func (ci *patchsetCI) updateTool(ctx context.Context, id int32, icon listssrht.ToolIcon, details string) error {
+	details = ciToolPrefix + details
+	tool, err := listssrht.UpdateTool(ci.lists.Client, ctx, id, &details, &icon)
+	if err != nil {
+		return err
+	} else if tool == nil {
+		return fmt.Errorf("failed to update tool with ID %d", id)
+	}
+	return nil
+}
+
+// watch polls a mailing list for proposed patchsets which haven't been
+// tested yet, and tests them.
+func (ci *patchsetCI) watch(ctx context.Context, name, owner string, interval time.Duration) error {
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	seen := make(map[int32]bool)
+	var jobs []*patchsetCIJob
+	for {
+		var (
+			user *listssrht.User
+			err  error
+		)
+		if username != "" {
+			user, err = listssrht.ListPatchesByUser(ci.lists.Client, ctx, username, name, nil)
+		} else {
+			user, err = listssrht.ListPatches(ci.lists.Client, ctx, name, nil)
+		}
+		if err != nil {
+			log.Printf("failed to list patchsets: %v", err)
+		} else if user == nil {
+			return fmt.Errorf("no such user %q", username)
+		} else if user.List == nil {
+			return fmt.Errorf("no such list %q", name)
+		} else {
+			for _, patchset := range user.List.Patches.Results {
+				if seen[patchset.Id] || patchset.Status != listssrht.PatchsetStatusProposed || hasCITool(&patchset) {
+					continue
+				}
+				job, err := ci.start(ctx, patchset.Id)
+				if err != nil {
+					// Retry on the next iteration
+					log.Printf("failed to test patchset %d: %v", patchset.Id, err)
+					continue
+				}
+				seen[patchset.Id] = true
+				if job != nil {
+					jobs = append(jobs, job)
+				}
+			}
+		}
+
+		var pending []*patchsetCIJob
+		for _, job := range jobs {
+			status, err := ci.poll(ctx, job)
+			if err != nil {
+				log.Printf("failed to check build for patchset %d: %v", job.patchsetID, err)
+			}
+			if !jobStatusDone(status) {
+				pending = append(pending, job)
+			}
+		}
+		jobs = pending
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+			// Continue looping
+		}
+	}
+}
+
+// resolveCIBase resolves the revision patchsets are applied on. Since builds
+// clone the remote repository, the commit must be on a remote-tracking branch.
+func resolveCIBase(rev string) (string, error) {
+	b, err := exec.Command("git", "rev-parse", "--verify", rev+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %q: %v", rev, err)
+	}
+	base := strings.TrimSpace(string(b))
+
+	b, err = exec.Command("git", "branch", "--remotes", "--contains", base).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to look up remote branches containing %q: %v", rev, err)
+	} else if len(bytes.TrimSpace(b)) == 0 {
+		return "", fmt.Errorf("%q (%.12s) has not been pushed to a remote", rev, base)
+	}
+
+	return base, nil
+}
+
+func hasCITool(patchset *listssrht.Patchset) bool {
+	for _, tool := range patchset.Tools {
+		if strings.HasPrefix(tool.Details, ciToolPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyCIPatchset applies the patchset on base in a scratch worktree, and
+// returns the build manifest of the patched tree.
+func applyCIPatchset(base, manifest string, mbox []byte) ([]byte, error) {
+	var b []byte
+	err := withPatchedWorktree(base, mbox, func(dir string) error {
+		var err error
+		b, err = os.ReadFile(filepath.Join(dir, manifest))
+		return err
+	})
+	return b, err
+}
+
+// rewriteCIManifest pins the source of the repository in a build manifest to
+// base, and adds a task applying the patchset on top of it.
+func rewriteCIManifest(b []byte, repoDir, base string, mbox []byte) ([]byte, error) {
+	if _, err := parseBuildManifest(b); err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %v", err)
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("invalid manifest: expected a mapping")
+	}
+	root := doc.Content[0]
+
+	found := false
+	if sources := yamlMappingValue(root, "sources"); sources != nil {
+		for _, node := range sources.Content {
+			source := parseBuildSource(node.Value)
+			if source.SCM != "git" || source.Dir != repoDir {
+				continue
+			}
+			if i := strings.LastIndex(node.Value, "#"); i >= 0 {
+				node.Value = node.Value[:i]
+			}
+			node.Value += "#" + base
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("invalid manifest: no source for repository %q", repoDir)
+	}
+
+	var script strings.Builder
+	fmt.Fprintf(&script, "cd %s\n", shellQuote(repoDir))
+	script.WriteString("base64 -d <<'EOF' | git -c user.name=builds.sr.ht -c user.email=builds@sr.ht am -3\n")
+	encoded := base64.StdEncoding.EncodeToString(mbox)
+	for len(encoded) > 76 {
+		script.WriteString(encoded[:76] + "\n")
+		encoded = encoded[76:]
+	}
+	script.WriteString(encoded + "\nEOF\n")
+
+	task := &yaml.Node{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: ciApplyTask},
+			{Kind: yaml.ScalarNode, Value: script.String(), Style: yaml.LiteralStyle},
+		},
+	}
+	tasks := yamlMappingValue(root, "tasks")
+	if tasks == nil {
+		tasks = &yaml.Node{Kind: yaml.SequenceNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "tasks"}, tasks)
+	}
+	tasks.Content = append([]*yaml.Node{task}, tasks.Content...)
+
+	return yaml.Marshal(&doc)
+}
+
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
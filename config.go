@@ -21,6 +21,7 @@ var tildeSlash = "~" + string(os.PathSeparator)
 
 type Config struct {
 	Instances []*InstanceConfig `scfg:"instance"`
+	Mail      *MailConfig       `scfg:"mail"`
 }
 
 type InstanceConfig struct {
@@ -77,6 +78,14 @@ type ServiceConfig struct {
 	Origin string `scfg:"origin"`
 }
 
+// MailConfig describes how hut sends emails.
+type MailConfig struct {
+	From            string   `scfg:"from"`
+	SMTP            string   `scfg:"smtp"`
+	SMTPPasswordCmd []string `scfg:"smtp-password-cmd"`
+	SendmailCmd     []string `scfg:"sendmail-cmd"`
+}
+
 func instancesEqual(a, b string) bool {
 	return a == b || strings.HasSuffix(a, "."+b) || strings.HasSuffix(b, "."+a)
 }
@@ -118,6 +127,18 @@ func loadConfigFile(filename string) (*Config, error) {
 		}
 	}
 
+	if mail := cfg.Mail; mail != nil {
+		if mail.SMTP != "" && mail.SendmailCmd != nil {
+			return nil, errors.New("mail: smtp and sendmail-cmd can't be both specified")
+		}
+		if mail.SendmailCmd != nil && len(mail.SendmailCmd) == 0 {
+			return nil, errors.New("mail: missing command name in sendmail-cmd directive")
+		}
+		if mail.SMTPPasswordCmd != nil && len(mail.SMTPPasswordCmd) == 0 {
+			return nil, errors.New("mail: missing command name in smtp-password-cmd directive")
+		}
+	}
+
 	return cfg, nil
 }
 
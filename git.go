@@ -1,22 +1,32 @@
 package main
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
 
 	"git.sr.ht/~emersion/gqlclient"
 	"github.com/dustin/go-humanize"
+	"github.com/juju/ansiterm/tabwriter"
 	"github.com/spf13/cobra"
 
 	"git.sr.ht/~xenrox/hut/srht/gitsrht"
+	"git.sr.ht/~xenrox/hut/srht/metasrht"
 	"git.sr.ht/~xenrox/hut/termfmt"
 )
 
@@ -26,8 +36,14 @@ func newGitCommand() *cobra.Command {
 		Short: "Use the git API",
 	}
 	cmd.AddCommand(newGitArtifactCommand())
+	cmd.AddCommand(newGitCatCommand())
 	cmd.AddCommand(newGitCreateCommand())
 	cmd.AddCommand(newGitListCommand())
+	cmd.AddCommand(newGitLogCommand())
+	cmd.AddCommand(newGitLsCommand())
+	cmd.AddCommand(newGitMirrorCommand())
+	cmd.AddCommand(newGitRefsCommand())
+	cmd.AddCommand(newGitReleaseCommand())
 	cmd.AddCommand(newGitDeleteCommand())
 	cmd.AddCommand(newGitCloneCommand())
 	cmd.AddCommand(newGitSetupCommand())
@@ -71,19 +87,11 @@ func newGitCreateCommand() *cobra.Command {
 
 		log.Printf("Created repository %q\n", repo.Name)
 
-		ver, err := gitsrht.SshSettings(c.Client, ctx)
+		cloneURL, err := getGitSSHCloneURL(ctx, c, repo.Owner.CanonicalName, repo.Name)
 		if err != nil {
-			log.Fatalf("failed to retrieve settings: %v", err)
-		}
-
-		u, err := url.Parse(c.BaseURL)
-		if err != nil {
-			log.Fatalf("failed to parse base URL: %v", err)
+			log.Fatal(err)
 		}
 
-		cloneURL := fmt.Sprintf("%s@%s:%s/%s", ver.Settings.SshUser, u.Hostname(),
-			repo.Owner.CanonicalName, repo.Name)
-
 		if clone {
 			cloneCmd := exec.Command("git", "clone", cloneURL)
 			cloneCmd.Stdin = os.Stdin
@@ -184,10 +192,30 @@ func printGitRepo(w io.Writer, repo *gitsrht.Repository) {
 }
 
 func newGitDeleteCommand() *cobra.Command {
-	var autoConfirm bool
+	var match string
+	var all, autoConfirm bool
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 
+		if match != "" || all {
+			if len(args) > 0 {
+				log.Fatal("--match and --all can't be used with a repository argument")
+			}
+			checkRepoPattern(match)
+
+			c := createClient("git", cmd)
+			repos, err := getGitReposMatching(c, ctx, match)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			runRepoBulk("delete", repos, autoConfirm, func(repo bulkRepo) error {
+				_, err := gitsrht.DeleteRepository(c.Client, ctx, repo.ID)
+				return err
+			})
+			return
+		}
+
 		var name, owner, instance string
 		if len(args) > 0 {
 			name, owner, instance = parseResourceName(args[0])
@@ -225,13 +253,44 @@ func newGitDeleteCommand() *cobra.Command {
 		Run:               run,
 	}
 	cmd.Flags().BoolVarP(&autoConfirm, "yes", "y", false, "auto confirm")
+	cmd.Flags().StringVar(&match, "match", "", "delete all repositories matching a glob pattern")
+	cmd.RegisterFlagCompletionFunc("match", cobra.NoFileCompletions)
+	cmd.Flags().BoolVar(&all, "all", false, "delete all repositories")
+	cmd.MarkFlagsMutuallyExclusive("match", "all")
 	return cmd
 }
 
 func newGitCloneCommand() *cobra.Command {
 	run := func(cmd *cobra.Command, args []string) {
-		log.Println("Cloning repository")
-		cloneCmd := exec.Command("git", "clone", args[0])
+		ctx := cmd.Context()
+
+		var gitArgs []string
+		if n := cmd.ArgsLenAtDash(); n >= 0 {
+			gitArgs = args[n:]
+			args = args[:n]
+		}
+
+		var cloneURL, name string
+		if isGitCloneURL(args[0]) {
+			cloneURL = args[0]
+			name = gitCloneURLRepoName(cloneURL)
+		} else {
+			var err error
+			cloneURL, name, err = resolveGitCloneURL(ctx, cmd, args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		dir := name
+		if len(args) > 1 {
+			dir = args[1]
+		}
+
+		log.Printf("Cloning %s into %q\n", cloneURL, dir)
+		cloneArgs := append([]string{"clone"}, gitArgs...)
+		cloneArgs = append(cloneArgs, "--", cloneURL, dir)
+		cloneCmd := exec.Command("git", cloneArgs...)
 		cloneCmd.Stdin = os.Stdin
 		cloneCmd.Stdout = os.Stdout
 		cloneCmd.Stderr = os.Stderr
@@ -241,13 +300,7 @@ func newGitCloneCommand() *cobra.Command {
 			log.Fatalf("failed to clone repo: %v", err)
 		}
 
-		s := strings.Split(args[0], "/")
-		dir, err := os.Getwd()
-		if err != nil {
-			log.Fatalf("failed to get current directory: %v", err)
-		}
-
-		err = os.Chdir(filepath.Join(dir, s[len(s)-1]))
+		err = os.Chdir(dir)
 		if err != nil {
 			log.Fatalf("failed to change current working directory: %v", err)
 		}
@@ -273,7 +326,7 @@ func newGitCloneCommand() *cobra.Command {
 			}
 
 			if cfg.PatchPrefix {
-				prefixCmd := exec.Command("git", "config", "format.subjectPrefix", fmt.Sprintf("PATCH %s", s[len(s)-1]))
+				prefixCmd := exec.Command("git", "config", "format.subjectPrefix", fmt.Sprintf("PATCH %s", name))
 				prefixCmd.Stdin = os.Stdin
 				prefixCmd.Stdout = os.Stdout
 				prefixCmd.Stderr = os.Stderr
@@ -286,15 +339,308 @@ func newGitCloneCommand() *cobra.Command {
 		}
 	}
 	cmd := &cobra.Command{
-		Use:               "clone <URL>",
-		Short:             "Clone a repository",
-		Args:              cobra.ExactArgs(1),
+		Use:   "clone <repo> [directory] [-- git-args...]",
+		Short: "Clone a repository",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if n := cmd.ArgsLenAtDash(); n >= 0 {
+				args = args[:n]
+			}
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completeGitRepo(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+		Run: run,
+	}
+	return cmd
+}
+
+// isGitCloneURL reports whether s is a URL, an scp-like address or a local
+// path understood by git, rather than a repository name.
+func isGitCloneURL(s string) bool {
+	if strings.Contains(s, "://") {
+		return true
+	}
+	if _, err := os.Stat(s); err == nil {
+		return true
+	}
+	i := strings.Index(s, ":")
+	return i > 0 && !strings.Contains(s[:i], "/")
+}
+
+// gitCloneURLRepoName returns the repository name git derives from a clone
+// URL.
+func gitCloneURLRepoName(s string) string {
+	s = stripProtocol(s)
+	if i := strings.Index(s, ":"); i >= 0 && !strings.Contains(s[:i], "/") {
+		s = s[i+1:]
+	}
+	s = strings.TrimRight(s, "/")
+	s = strings.TrimSuffix(s, ".git")
+	return s[strings.LastIndex(s, "/")+1:]
+}
+
+// resolveGitCloneURL resolves a repository name to a clone URL. SSH is used
+// if the user has an SSH key registered, HTTPS otherwise.
+func resolveGitCloneURL(ctx context.Context, cmd *cobra.Command, repo string) (cloneURL, name string, err error) {
+	name, owner, instance := parseResourceName(repo)
+	c := createClientWithInstance("git", cmd, instance)
+
+	var user *gitsrht.User
+	if owner == "" {
+		user, err = gitsrht.CloneRepository(c.Client, ctx, name)
+	} else {
+		username := strings.TrimLeft(owner, ownerPrefixes)
+		user, err = gitsrht.CloneRepositoryByUser(c.Client, ctx, username, name)
+	}
+	if err != nil {
+		return "", "", err
+	} else if user == nil {
+		return "", "", fmt.Errorf("no such user %q", owner)
+	} else if user.Repository == nil {
+		return "", "", fmt.Errorf("no such repository %q", formatResourceName(name, owner))
+	}
+	owner = user.CanonicalName
+
+	mc := createClientWithInstance("meta", cmd, instance)
+	if me, err := metasrht.SshKeyIDs(mc.Client, ctx); err == nil && len(me.SshKeys.Results) > 0 {
+		cloneURL, err = getGitSSHCloneURL(ctx, c, owner, name)
+		return cloneURL, name, err
+	}
+
+	return fmt.Sprintf("%s/%s/%s", c.BaseURL, owner, name), name, nil
+}
+
+func newGitMirrorCommand() *cobra.Command {
+	var filename, cacheDir, visibility string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		var mirrors []gitMirror
+		if filename != "" {
+			if len(args) > 0 {
+				log.Fatal("--file can't be used with arguments")
+			}
+
+			var err error
+			mirrors, err = readGitMirrorFile(filename)
+			if err != nil {
+				log.Fatal(err)
+			}
+		} else if len(args) > 0 {
+			mirror := gitMirror{Source: args[0]}
+			if len(args) > 1 {
+				mirror.Target = args[1]
+			}
+			mirrors = append(mirrors, mirror)
+		} else {
+			log.Fatal("either a source URL or --file is required")
+		}
+
+		gitVisibility, err := gitsrht.ParseVisibility(visibility)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if cacheDir == "" {
+			dir, err := os.UserCacheDir()
+			if err != nil {
+				log.Fatalf("failed to find cache directory: %v", err)
+			}
+			cacheDir = filepath.Join(dir, "hut", "mirrors")
+		}
+		if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+			log.Fatalf("failed to create cache directory: %v", err)
+		}
+
+		// Targets may live on different instances
+		clients := make(map[string]*Client)
+		var failed int
+		for _, mirror := range mirrors {
+			_, _, instance := parseResourceName(mirror.Target)
+			c, ok := clients[instance]
+			if !ok {
+				c = createClientWithInstance("git", cmd, instance)
+				clients[instance] = c
+			}
+
+			if err := syncGitMirror(ctx, c, &mirror, cacheDir, gitVisibility); err != nil {
+				log.Printf("Failed to mirror %s: %v\n", mirror.Source, err)
+				failed++
+			}
+		}
+
+		if len(mirrors) > 1 {
+			log.Printf("Mirrored %d repositories, %d failed\n", len(mirrors)-failed, failed)
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "mirror [source-url] [name]",
+		Short:             "Mirror a repository from another forge",
+		Args:              cobra.MaximumNArgs(2),
 		ValidArgsFunction: cobra.NoFileCompletions,
 		Run:               run,
 	}
+	cmd.Flags().StringVarP(&filename, "file", "f", "", "file listing mirrors")
+	cmd.Flags().StringVar(&cacheDir, "cache", "", "directory for bare repository caches")
+	cmd.MarkFlagDirname("cache")
+	cmd.Flags().StringVarP(&visibility, "visibility", "v", "public", "visibility of created repositories")
+	cmd.RegisterFlagCompletionFunc("visibility", completeVisibility)
 	return cmd
 }
 
+// gitMirror is a source repository mirrored to a git.sr.ht repository. An
+// empty target defaults to the name of the source repository.
+type gitMirror struct {
+	Source string
+	Target string
+}
+
+// readGitMirrorFile reads a file listing one mirror per line, as a source URL
+// optionally followed by a target repository. Empty lines and lines starting
+// with "#" are ignored.
+func readGitMirrorFile(filename string) ([]gitMirror, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var mirrors []gitMirror
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[1] == "->" {
+			fields = []string{fields[0], fields[2]}
+		}
+
+		switch len(fields) {
+		case 1:
+			mirrors = append(mirrors, gitMirror{Source: fields[0]})
+		case 2:
+			mirrors = append(mirrors, gitMirror{Source: fields[0], Target: fields[1]})
+		default:
+			return nil, fmt.Errorf("%s:%d: expected a source URL and an optional target", filename, i+1)
+		}
+	}
+	return mirrors, nil
+}
+
+// gitMirrorRepoName returns the default repository name for a source URL.
+func gitMirrorRepoName(source string) string {
+	name := strings.TrimRight(source, "/")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, ".git")
+}
+
+// syncGitMirror creates the target repository if missing, fetches the source
+// into a bare cache and pushes all branches and tags to the target.
+func syncGitMirror(ctx context.Context, c *Client, mirror *gitMirror, cacheDir string, visibility gitsrht.Visibility) error {
+	target := mirror.Target
+	if target == "" {
+		target = gitMirrorRepoName(mirror.Source)
+	}
+	name, owner, _ := parseResourceName(target)
+	if name == "" {
+		return fmt.Errorf("invalid target %q", target)
+	}
+
+	if owner == "" {
+		user, err := gitsrht.MirrorTarget(c.Client, ctx, name)
+		if err != nil {
+			return err
+		}
+		owner = user.CanonicalName
+
+		if user.Repository == nil {
+			desc := "Mirror of " + mirror.Source
+			_, err := gitsrht.CreateRepository(c.Client, ctx, name, visibility, &desc, nil)
+			if err != nil {
+				return fmt.Errorf("failed to create repository: %v", err)
+			}
+			log.Printf("Created repository %q\n", name)
+		}
+	}
+
+	pushURL, err := getGitSSHCloneURL(ctx, c, owner, name)
+	if err != nil {
+		return err
+	}
+
+	cache := filepath.Join(cacheDir, gitMirrorCacheName(mirror.Source))
+	if _, err := os.Stat(cache); errors.Is(err, os.ErrNotExist) {
+		if err := runGitCommand("init", "--quiet", "--bare", cache); err != nil {
+			return fmt.Errorf("failed to create cache: %v", err)
+		}
+		err = runGitCommand("-C", cache, "remote", "add", "origin", mirror.Source)
+		if err == nil {
+			err = runGitCommand("-C", cache, "config", "remote.origin.fetch", "+refs/heads/*:refs/heads/*")
+		}
+		if err == nil {
+			err = runGitCommand("-C", cache, "config", "--add", "remote.origin.fetch", "+refs/tags/*:refs/tags/*")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to configure cache: %v", err)
+		}
+	} else if err != nil {
+		return err
+	} else if err := runGitCommand("-C", cache, "remote", "set-url", "origin", mirror.Source); err != nil {
+		return fmt.Errorf("failed to configure cache: %v", err)
+	}
+
+	log.Printf("Fetching %s\n", mirror.Source)
+	if err := runGitCommand("-C", cache, "fetch", "--prune", "origin"); err != nil {
+		return fmt.Errorf("failed to fetch: %v", err)
+	}
+
+	log.Printf("Pushing to %s/%s\n", owner, name)
+	if err := runGitCommand("-C", cache, "push", "--mirror", pushURL); err != nil {
+		return fmt.Errorf("failed to push: %v", err)
+	}
+
+	return nil
+}
+
+// gitMirrorCacheName returns the name of the cache directory of a source URL.
+// The name of the source repository is kept for readability, and a hash of
+// the full URL avoids collisions between sources.
+func gitMirrorCacheName(source string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, gitMirrorRepoName(source))
+	sum := sha256.Sum256([]byte(source))
+	return fmt.Sprintf("%s-%x.git", name, sum[:16])
+}
+
+func getGitSSHCloneURL(ctx context.Context, c *Client, owner, name string) (string, error) {
+	ver, err := gitsrht.SshSettings(c.Client, ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve settings: %v", err)
+	}
+
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse base URL: %v", err)
+	}
+
+	return fmt.Sprintf("%s@%s:%s/%s", ver.Settings.SshUser, u.Hostname(), owner, name), nil
+}
+
 func newGitSetupCommand() *cobra.Command {
 	var force bool
 	run := func(cmd *cobra.Command, args []string) {
@@ -380,6 +726,7 @@ func newGitArtifactCommand() *cobra.Command {
 	cmd.AddCommand(newGitArtifactUploadCommand())
 	cmd.AddCommand(newGitArtifactListCommand())
 	cmd.AddCommand(newGitArtifactDeleteCommand())
+	cmd.AddCommand(newGitArtifactDownloadCommand())
 	return cmd
 }
 
@@ -436,8 +783,8 @@ func newGitArtifactUploadCommand() *cobra.Command {
 }
 
 func newGitArtifactListCommand() *cobra.Command {
-	// TODO: Filter by rev
-
+	var rev string
+	var checksums bool
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 		repoName, owner, instance, err := getGitRepoName(ctx, cmd)
@@ -447,30 +794,32 @@ func newGitArtifactListCommand() *cobra.Command {
 
 		c := createClientWithInstance("git", cmd, instance)
 
-		var (
-			username string
-			user     *gitsrht.User
-		)
-
-		if owner != "" {
-			username = strings.TrimLeft(owner, ownerPrefixes)
-			user, err = gitsrht.ListArtifactsByUser(c.Client, ctx, username, repoName)
-		} else {
-			user, err = gitsrht.ListArtifacts(c.Client, ctx, repoName)
+		if checksums && rev == "" {
+			rev, err = guessRev()
+			if err != nil {
+				log.Fatal(err)
+			}
 		}
 
+		refs, err := getGitArtifacts(ctx, c, repoName, owner)
 		if err != nil {
 			log.Fatal(err)
-		} else if user == nil {
-			log.Fatalf("no such user %q", username)
-		} else if user.Repository == nil {
-			log.Fatalf("no such repository %q", repoName)
 		}
 
-		for _, ref := range user.Repository.References.Results {
+		for _, ref := range refs {
 			if len(ref.Artifacts.Results) == 0 {
 				continue
 			}
+			if rev != "" && !gitRefMatches(ref.Name, rev) {
+				continue
+			}
+
+			if checksums {
+				for _, artifact := range ref.Artifacts.Results {
+					fmt.Printf("%s  %s\n", strings.TrimPrefix(artifact.Checksum, "sha256:"), artifact.Filename)
+				}
+				continue
+			}
 
 			name := ref.Name[strings.LastIndex(ref.Name, "/")+1:]
 			fmt.Printf("Tag %s:\n", termfmt.Bold.String(name))
@@ -486,73 +835,289 @@ func newGitArtifactListCommand() *cobra.Command {
 		Args:  cobra.ExactArgs(0),
 		Run:   run,
 	}
+	cmd.Flags().StringVar(&rev, "rev", "", "revision tag")
+	cmd.RegisterFlagCompletionFunc("rev", completeRev)
+	cmd.Flags().BoolVar(&checksums, "checksums", false, "print checksums in SHA256SUMS format")
 	return cmd
 }
 
-func newGitArtifactDeleteCommand() *cobra.Command {
+func newGitArtifactDownloadCommand() *cobra.Command {
+	var rev, dir string
+	var all bool
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		c := createClient("git", cmd)
-
-		id, err := parseInt32(args[0])
+		repoName, owner, instance, err := getGitRepoName(ctx, cmd)
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		artifact, err := gitsrht.DeleteArtifact(c.Client, ctx, id)
+		if len(args) == 0 && !all {
+			log.Fatal("no artifact specified, use --all to download all artifacts")
+		}
+
+		c := createClientWithInstance("git", cmd, instance)
+		c.HTTP.Timeout = fileTransferTimeout
+
+		if rev == "" {
+			rev, err = guessRev()
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		refs, err := getGitArtifacts(ctx, c, repoName, owner)
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		log.Printf("Deleted artifact %q\n", artifact.Filename)
+		var artifacts []gitsrht.Artifact
+		for _, ref := range refs {
+			if gitRefMatches(ref.Name, rev) {
+				artifacts = ref.Artifacts.Results
+				break
+			}
+		}
+
+		if !all {
+			var selected []gitsrht.Artifact
+			for _, filename := range args {
+				i := slices.IndexFunc(artifacts, func(artifact gitsrht.Artifact) bool {
+					return artifact.Filename == filename
+				})
+				if i < 0 {
+					log.Fatalf("no artifact %q for revision %q", filename, rev)
+				}
+				selected = append(selected, artifacts[i])
+			}
+			artifacts = selected
+		}
+
+		if len(artifacts) == 0 {
+			log.Fatalf("no artifacts for revision %q", rev)
+		}
+
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			log.Fatalf("failed to create output directory: %v", err)
+		}
+
+		for _, artifact := range artifacts {
+			if err := downloadGitArtifact(ctx, c, &artifact, dir); err != nil {
+				log.Fatalf("failed to download %q: %v", artifact.Filename, err)
+			}
+			log.Printf("Downloaded %q\n", artifact.Filename)
+		}
 	}
 
 	cmd := &cobra.Command{
-		Use:               "delete <ID>",
-		Short:             "Delete an artifact",
-		Args:              cobra.ExactArgs(1),
-		ValidArgsFunction: completeArtifacts,
+		Use:               "download [filename...]",
+		Short:             "Download artifacts",
+		ValidArgsFunction: cobra.NoFileCompletions,
 		Run:               run,
 	}
+	cmd.Flags().StringVar(&rev, "rev", "", "revision tag")
+	cmd.RegisterFlagCompletionFunc("rev", completeRev)
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "download all artifacts of the revision")
+	cmd.Flags().StringVarP(&dir, "output", "o", ".", "output directory")
+	cmd.MarkFlagDirname("output")
 	return cmd
 }
 
-func newGitACLCommand() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "acl",
-		Short: "Manage access-control lists",
+// downloadGitArtifact downloads an artifact into dir and verifies its
+// checksum. The file is only created if the checksum matches.
+func downloadGitArtifact(ctx context.Context, c *Client, artifact *gitsrht.Artifact, dir string) error {
+	algo, want, ok := strings.Cut(artifact.Checksum, ":")
+	if !ok || algo != "sha256" {
+		return fmt.Errorf("unsupported checksum %q", artifact.Checksum)
 	}
-	cmd.AddCommand(newGitACLListCommand())
-	cmd.AddCommand(newGitACLUpdateCommand())
-	cmd.AddCommand(newGitACLDeleteCommand())
-	return cmd
-}
 
-func newGitACLListCommand() *cobra.Command {
-	var count int
-	run := func(cmd *cobra.Command, args []string) {
-		ctx := cmd.Context()
-		var name, owner, instance string
-		if len(args) > 0 {
-			name, owner, instance = parseResourceName(args[0])
-		} else {
-			var err error
-			name, owner, instance, err = getGitRepoName(ctx, cmd)
-			if err != nil {
-				log.Fatal(err)
-			}
-		}
+	f, err := os.CreateTemp(dir, ".hut-artifact-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
 
-		c := createClientWithInstance("git", cmd, instance)
-		var (
-			cursor   *gitsrht.Cursor
-			user     *gitsrht.User
-			username string
-			err      error
-		)
-		if owner != "" {
-			username = strings.TrimLeft(owner, ownerPrefixes)
-		}
+	h := sha256.New()
+	if err := fetchGitBlob(ctx, c, string(artifact.Url), io.MultiWriter(f, h)); err != nil {
+		return err
+	}
+
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", want, got)
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, filepath.Base(artifact.Filename)))
+}
+
+// writeFileAtomic writes a file via a temporary file in the same directory,
+// so that no partial file is left behind if fn fails.
+func writeFileAtomic(filename string, fn func(w io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(filename), ".hut-*")
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := fn(f); err != nil {
+		return err
+	}
+	if err := f.Chmod(0644); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filename)
+}
+
+func getGitArtifacts(ctx context.Context, c *Client, repoName, owner string) ([]gitsrht.Reference, error) {
+	var (
+		username string
+		cursor   *gitsrht.Cursor
+		refs     []gitsrht.Reference
+	)
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	for {
+		var (
+			user *gitsrht.User
+			err  error
+		)
+		if username != "" {
+			user, err = gitsrht.ListArtifactsByUser(c.Client, ctx, username, repoName, cursor)
+		} else {
+			user, err = gitsrht.ListArtifacts(c.Client, ctx, repoName, cursor)
+		}
+
+		if err != nil {
+			return nil, err
+		} else if user == nil {
+			return nil, fmt.Errorf("no such user %q", username)
+		} else if user.Repository == nil {
+			return nil, fmt.Errorf("no such repository %q", repoName)
+		}
+
+		for _, ref := range user.Repository.References.Results {
+			// Fetch the remaining artifacts of references with many of them
+			for artifactsCursor := ref.Artifacts.Cursor; artifactsCursor != nil; {
+				var artifacts *gitsrht.ArtifactCursor
+				artifacts, err = getGitReferenceArtifacts(ctx, c, repoName, username, ref.Name, artifactsCursor)
+				if err != nil {
+					return nil, err
+				}
+				ref.Artifacts.Results = append(ref.Artifacts.Results, artifacts.Results...)
+				artifactsCursor = artifacts.Cursor
+			}
+			refs = append(refs, ref)
+		}
+
+		cursor = user.Repository.References.Cursor
+		if cursor == nil {
+			return refs, nil
+		}
+	}
+}
+
+func getGitReferenceArtifacts(ctx context.Context, c *Client, repoName, username, refName string, cursor *gitsrht.Cursor) (*gitsrht.ArtifactCursor, error) {
+	var (
+		user *gitsrht.User
+		err  error
+	)
+	if username != "" {
+		user, err = gitsrht.ReferenceArtifactsByUser(c.Client, ctx, username, repoName, refName, cursor)
+	} else {
+		user, err = gitsrht.ReferenceArtifacts(c.Client, ctx, repoName, refName, cursor)
+	}
+
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, fmt.Errorf("no such user %q", username)
+	} else if user.Repository == nil {
+		return nil, fmt.Errorf("no such repository %q", repoName)
+	} else if user.Repository.Reference == nil {
+		return nil, fmt.Errorf("no such reference %q", refName)
+	}
+	return user.Repository.Reference.Artifacts, nil
+}
+
+// gitRefMatches reports whether the fully qualified reference name matches
+// rev, which can be either a tag name or a fully qualified name.
+func gitRefMatches(name, rev string) bool {
+	return name == rev || name == "refs/tags/"+rev
+}
+
+func newGitArtifactDeleteCommand() *cobra.Command {
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		c := createClient("git", cmd)
+
+		id, err := parseInt32(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		artifact, err := gitsrht.DeleteArtifact(c.Client, ctx, id)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Printf("Deleted artifact %q\n", artifact.Filename)
+	}
+
+	cmd := &cobra.Command{
+		Use:               "delete <ID>",
+		Short:             "Delete an artifact",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArtifacts,
+		Run:               run,
+	}
+	return cmd
+}
+
+func newGitACLCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Manage access-control lists",
+	}
+	cmd.AddCommand(newGitACLListCommand())
+	cmd.AddCommand(newGitACLUpdateCommand())
+	cmd.AddCommand(newGitACLDeleteCommand())
+	cmd.AddCommand(newGitACLApplyCommand())
+	return cmd
+}
+
+func newGitACLListCommand() *cobra.Command {
+	var count int
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		var name, owner, instance string
+		if len(args) > 0 {
+			name, owner, instance = parseResourceName(args[0])
+		} else {
+			var err error
+			name, owner, instance, err = getGitRepoName(ctx, cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClientWithInstance("git", cmd, instance)
+		var (
+			cursor   *gitsrht.Cursor
+			user     *gitsrht.User
+			username string
+			err      error
+		)
+		if owner != "" {
+			username = strings.TrimLeft(owner, ownerPrefixes)
+		}
 
 		err = pagerify(func(p pager) error {
 			if username != "" {
@@ -659,132 +1224,1185 @@ func newGitACLDeleteCommand() *cobra.Command {
 		ctx := cmd.Context()
 		c := createClient("git", cmd)
 
-		id, err := parseInt32(args[0])
-		if err != nil {
+		id, err := parseInt32(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		acl, err := gitsrht.DeleteACL(c.Client, ctx, id)
+		if err != nil {
+			log.Fatal(err)
+		} else if acl == nil {
+			log.Fatalf("failed to delete ACL entry with ID %d", id)
+		}
+
+		log.Printf("Deleted ACL entry for %q in repository %q\n", acl.Entity.CanonicalName, acl.Repository.Name)
+	}
+
+	cmd := &cobra.Command{
+		Use:               "delete <ID>",
+		Short:             "Delete an ACL entry",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	return cmd
+}
+
+func newGitACLApplyCommand() *cobra.Command {
+	var filename string
+	var prune, dryRun bool
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		resources, err := readACLFile(filename, "repo", aclModePermissions(func(s string) (string, error) {
+			mode, err := gitsrht.ParseAccessMode(s)
+			return string(mode), err
+		}))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, resource := range resources {
+			var name, owner, instance string
+			if resource.Name != "" {
+				name, owner, instance = parseResourceName(resource.Name)
+			} else if len(args) > 0 {
+				name, owner, instance = parseResourceName(args[0])
+			} else {
+				name, owner, instance, err = getGitRepoName(ctx, cmd)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			c := createClientWithInstance("git", cmd, instance)
+			backend := newGitACLBackend(c, name, owner)
+			if err := applyACL(ctx, formatResourceName(name, owner), backend, resource.Entries, prune, dryRun); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "apply [repo]",
+		Short:             "Apply ACL entries from a file",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeGitRepo,
+		Run:               run,
+	}
+	cmd.Flags().StringVarP(&filename, "file", "f", "", "ACL file")
+	cmd.MarkFlagRequired("file")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete entries not listed in the file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the changes")
+	return cmd
+}
+
+func newGitACLBackend(c *Client, name, owner string) *aclBackend {
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	var repoID int32
+	return &aclBackend{
+		List: func(ctx context.Context) ([]aclEntry, error) {
+			var (
+				entries []aclEntry
+				cursor  *gitsrht.Cursor
+				user    *gitsrht.User
+				err     error
+			)
+			for {
+				if username != "" {
+					user, err = gitsrht.AclByUser(c.Client, ctx, username, name, cursor)
+				} else {
+					user, err = gitsrht.AclByRepoName(c.Client, ctx, name, cursor)
+				}
+
+				if err != nil {
+					return nil, err
+				} else if user == nil {
+					return nil, fmt.Errorf("no such user %q", username)
+				} else if user.Repository == nil {
+					return nil, fmt.Errorf("no such repository %q", name)
+				}
+
+				for _, acl := range user.Repository.Acls.Results {
+					var permissions []string
+					if acl.Mode != nil {
+						permissions = []string{string(*acl.Mode)}
+					}
+					entries = append(entries, aclEntry{ID: acl.Id, Entity: acl.Entity.CanonicalName, Permissions: permissions})
+				}
+
+				cursor = user.Repository.Acls.Cursor
+				if cursor == nil {
+					return entries, nil
+				}
+			}
+		},
+		Update: func(ctx context.Context, entity string, permissions []string) error {
+			if repoID == 0 {
+				id, err := getGitRepoID(c, ctx, name, owner)
+				if err != nil {
+					return err
+				}
+				repoID = id
+			}
+
+			_, err := gitsrht.UpdateACL(c.Client, ctx, repoID, gitsrht.AccessMode(permissions[0]), entity)
+			return err
+		},
+		Delete: func(ctx context.Context, id int32) error {
+			_, err := gitsrht.DeleteACL(c.Client, ctx, id)
+			return err
+		},
+	}
+}
+
+func newGitShowCommand() *cobra.Command {
+	var web bool
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		repoFlag, err := cmd.Flags().GetString("repo")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// A single argument is a revision if --repo is set, and a repository
+		// otherwise
+		var name, owner, instance, rev string
+		if len(args) == 2 {
+			name, owner, instance = parseResourceName(args[0])
+			rev = args[1]
+		} else if len(args) == 1 && repoFlag == "" {
+			name, owner, instance = parseResourceName(args[0])
+		} else {
+			name, owner, instance, err = getGitRepoName(ctx, cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if len(args) == 1 {
+				rev = args[0]
+			}
+		}
+
+		c := createClientWithInstance("git", cmd, instance)
+
+		if rev != "" {
+			showGitCommit(cmd, c, name, owner, rev, web)
+			return
+		}
+
+		var (
+			user     *gitsrht.User
+			username string
+		)
+		if owner == "" {
+			user, err = gitsrht.RepositoryByName(c.Client, ctx, name)
+		} else {
+			username = strings.TrimLeft(owner, ownerPrefixes)
+			user, err = gitsrht.RepositoryByUser(c.Client, ctx, username, name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		} else if user == nil {
+			log.Fatalf("no such user %q", username)
+		} else if user.Repository == nil {
+			log.Fatalf("no such repository %q", name)
+		}
+		repo := user.Repository
+		repoUrl := fmt.Sprintf("%s/%s/%s", c.BaseURL, owner, name)
+
+		if web {
+			err := openURL(repoUrl)
+			if err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		}
+
+		// prints url for easy access
+		fmt.Printf("%s\n\n", repoUrl)
+
+		// prints basic information
+		fmt.Printf("%s (%s)\n", termfmt.Bold.String(repo.Name), repo.Visibility.TermString())
+		if repo.Description != nil && *repo.Description != "" {
+			fmt.Printf("  %s\n", *repo.Description)
+		}
+
+		// prints latest tag
+		tags := repo.References.Tags()
+		if len(tags) > 0 {
+			fmt.Println()
+			fmt.Printf("  Latest tag: %s\n", tags[len(tags)-1])
+		}
+
+		// prints branches
+		branches := repo.References.Heads()
+		if len(branches) > 0 {
+			fmt.Println()
+			fmt.Printf("  Branches:\n")
+			for i := 0; i < len(branches); i++ {
+				fmt.Printf("    %s\n", branches[i])
+			}
+		}
+
+		// prints the three most recent commits
+		if len(repo.Log.Results) >= 3 {
+			fmt.Println()
+			fmt.Printf("  Recent log:\n")
+
+			for _, commit := range repo.Log.Results[:3] {
+				fmt.Printf("    %s %s <%s> (%s)\n",
+					termfmt.Yellow.Sprintf("%s", commit.ShortId),
+					commit.Author.Name,
+					commit.Author.Email,
+					humanize.Time(commit.Author.Time.Time))
+
+				commitLines := strings.Split(commit.Message, "\n")
+				fmt.Printf("      %s\n", commitLines[0])
+			}
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:   "show [repo] [rev]",
+		Short: "Shows a repository or a commit",
+		Args:  cobra.MaximumNArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if repoFlag, _ := cmd.Flags().GetString("repo"); len(args) == 0 && repoFlag == "" {
+				return completeGitRepo(cmd, args, toComplete)
+			}
+			return completeBranches(cmd, args, toComplete)
+		},
+		Run: run,
+	}
+	cmd.Flags().BoolVar(&web, "web", false, "open in browser")
+
+	return cmd
+}
+
+func showGitCommit(cmd *cobra.Command, c *Client, name, owner, rev string, web bool) {
+	ctx := cmd.Context()
+
+	var (
+		user     *gitsrht.User
+		username string
+		err      error
+	)
+	if owner == "" {
+		user, err = gitsrht.CommitByRepoName(c.Client, ctx, name, rev)
+	} else {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+		user, err = gitsrht.CommitByUser(c.Client, ctx, username, name, rev)
+	}
+	if err != nil {
+		log.Fatal(err)
+	} else if user == nil {
+		log.Fatalf("no such user %q", username)
+	} else if user.Repository == nil {
+		log.Fatalf("no such repository %q", name)
+	} else if user.Repository.Revparse_single == nil {
+		log.Fatalf("no such revision %q", rev)
+	}
+	commit := user.Repository.Revparse_single
+
+	if web {
+		commitURL := fmt.Sprintf("%s/%s/%s/commit/%s", c.BaseURL,
+			user.Repository.Owner.CanonicalName, name, commit.Id)
+		err := openURL(commitURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	err = pagerify(func(p pager) error {
+		printGitCommit(p, commit)
+		printGitDiff(p, commit.Diff)
+		return pagerDone
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newGitLogCommand() *cobra.Command {
+	var from string
+	var count int
+	var oneline bool
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		name, owner, instance, err := getGitRepoName(ctx, cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		c := createClientWithInstance("git", cmd, instance)
+
+		var fromPtr *string
+		if from != "" {
+			fromPtr = &from
+		}
+
+		var (
+			cursor   *gitsrht.Cursor
+			username string
+			printed  int
+		)
+		if owner != "" {
+			username = strings.TrimLeft(owner, ownerPrefixes)
+		}
+
+		err = pagerify(func(p pager) error {
+			var user *gitsrht.User
+			if username != "" {
+				user, err = gitsrht.LogByUser(c.Client, ctx, username, name, fromPtr, cursor)
+			} else {
+				user, err = gitsrht.LogByRepoName(c.Client, ctx, name, fromPtr, cursor)
+			}
+
+			if err != nil {
+				return err
+			} else if user == nil {
+				return errors.New("no such user")
+			} else if user.Repository == nil {
+				return fmt.Errorf("no such repository %q", name)
+			}
+
+			commits := user.Repository.Log
+			results := commits.Results
+			if count > 0 && printed+len(results) > count {
+				results = results[:count-printed]
+			}
+
+			for _, commit := range results {
+				if oneline {
+					printGitCommitOneline(p, &commit)
+				} else {
+					printGitCommit(p, &commit)
+				}
+			}
+			printed += len(results)
+
+			cursor = commits.Cursor
+			if p.IsDone(cursor, len(results)) {
+				return pagerDone
+			}
+
+			return nil
+		}, count)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:   "log",
+		Short: "Show the commit log",
+		Args:  cobra.ExactArgs(0),
+		Run:   run,
+	}
+	cmd.Flags().StringVar(&from, "from", "", "revision to start from")
+	cmd.RegisterFlagCompletionFunc("from", completeBranches)
+	cmd.Flags().IntVar(&count, "count", 0, "number of commits to fetch")
+	cmd.RegisterFlagCompletionFunc("count", cobra.NoFileCompletions)
+	cmd.Flags().BoolVar(&oneline, "oneline", false, "print one commit per line")
+	return cmd
+}
+
+func newGitLsCommand() *cobra.Command {
+	var rev string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		name, owner, instance, err := getGitRepoName(ctx, cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		c := createClientWithInstance("git", cmd, instance)
+
+		var path string
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		// Fetch all pages first, so that columns are aligned across pages
+		var (
+			cursor  *gitsrht.Cursor
+			entries []gitsrht.TreeEntry
+		)
+		for {
+			entry, err := getGitTreeEntry(ctx, c, name, owner, rev, path, cursor)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			tree, ok := entry.Object.Value.(*gitsrht.Tree)
+			if !ok {
+				entries = append(entries, *entry)
+				break
+			}
+
+			entries = append(entries, tree.Entries.Results...)
+			cursor = tree.Entries.Cursor
+			if cursor == nil {
+				break
+			}
+		}
+
+		err = pagerify(func(p pager) error {
+			tw := tabwriter.NewWriter(p, 0, 2, 2, ' ', 0)
+			defer tw.Flush()
+			for _, entry := range entries {
+				printGitTreeEntry(tw, &entry)
+			}
+			return pagerDone
+		}, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "ls [path]",
+		Short:             "List files of a repository",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().StringVar(&rev, "rev", "HEAD", "revision")
+	cmd.RegisterFlagCompletionFunc("rev", completeBranches)
+	return cmd
+}
+
+func printGitTreeEntry(w io.Writer, entry *gitsrht.TreeEntry) {
+	size := "-"
+	if _, blobSize, ok := gitBlob(entry.Object); ok {
+		size = humanize.Bytes(uint64(blobSize))
+	}
+
+	name := entry.Name
+	if entry.Object.Type == gitsrht.ObjectTypeTree {
+		name = termfmt.Bold.String(name + "/")
+	}
+
+	fmt.Fprintf(w, "%06o\t%s\t%s\n", entry.Mode, size, name)
+}
+
+func newGitCatCommand() *cobra.Command {
+	var rev, output string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		name, owner, instance, err := getGitRepoName(ctx, cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		c := createClientWithInstance("git", cmd, instance)
+		c.HTTP.Timeout = fileTransferTimeout
+
+		entry, err := getGitTreeEntry(ctx, c, name, owner, rev, args[0], nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		tree, isTree := entry.Object.Value.(*gitsrht.Tree)
+		contentURL, _, isBlob := gitBlob(entry.Object)
+		if !isTree && !isBlob {
+			log.Fatalf("cannot download %s object %q", strings.ToLower(string(entry.Object.Type)), args[0])
+		}
+
+		if isTree && output == "" && isStdoutTerminal {
+			log.Fatal("refusing to write a tarball to a terminal, use --output")
+		}
+
+		write := func(w io.Writer) error {
+			if isBlob {
+				return fetchGitBlob(ctx, c, contentURL, w)
+			}
+
+			var gw *gzip.Writer
+			if strings.HasSuffix(output, ".gz") || strings.HasSuffix(output, ".tgz") {
+				gw = gzip.NewWriter(w)
+				w = gw
+			}
+
+			prefix := entry.Name
+			if prefix == "" {
+				prefix = name
+			}
+
+			tw := tar.NewWriter(w)
+			err := writeGitTreeArchive(ctx, c, tw, name, owner, rev, strings.Trim(args[0], "/"), prefix, tree)
+			if err == nil {
+				err = tw.Close()
+			}
+			if err == nil && gw != nil {
+				err = gw.Close()
+			}
+			if err != nil {
+				return fmt.Errorf("failed to write tarball: %v", err)
+			}
+			return nil
+		}
+
+		if output == "" {
+			err = write(os.Stdout)
+		} else {
+			err = writeFileAtomic(output, write)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "cat <path>",
+		Short:             "Download a file or directory of a repository",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().StringVar(&rev, "rev", "HEAD", "revision")
+	cmd.RegisterFlagCompletionFunc("rev", completeBranches)
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output file")
+	return cmd
+}
+
+// writeGitTreeArchive recursively writes the tree at path to tw, with file
+// names relative to prefix.
+func writeGitTreeArchive(ctx context.Context, c *Client, tw *tar.Writer, name, owner, rev, path, prefix string, tree *gitsrht.Tree) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     prefix + "/",
+		Mode:     0755,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		for _, entry := range tree.Entries.Results {
+			entryPath := strings.TrimPrefix(path+"/"+entry.Name, "/")
+			entryName := prefix + "/" + entry.Name
+
+			if entry.Object.Type == gitsrht.ObjectTypeTree {
+				subEntry, err := getGitTreeEntry(ctx, c, name, owner, rev, entryPath, nil)
+				if err != nil {
+					return err
+				}
+				subTree, ok := subEntry.Object.Value.(*gitsrht.Tree)
+				if !ok {
+					return fmt.Errorf("%q is not a tree", entryPath)
+				}
+				if err := writeGitTreeArchive(ctx, c, tw, name, owner, rev, entryPath, entryName, subTree); err != nil {
+					return err
+				}
+				continue
+			}
+
+			contentURL, size, ok := gitBlob(entry.Object)
+			if !ok {
+				// Submodules point to commits in other repositories
+				log.Printf("Skipping %q", entryPath)
+				continue
+			}
+
+			if entry.Mode&0170000 == 0120000 {
+				var target strings.Builder
+				if err := fetchGitBlob(ctx, c, contentURL, &target); err != nil {
+					return err
+				}
+				err := tw.WriteHeader(&tar.Header{
+					Typeflag: tar.TypeSymlink,
+					Name:     entryName,
+					Linkname: target.String(),
+					Mode:     0777,
+				})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     entryName,
+				Size:     int64(size),
+				Mode:     int64(entry.Mode & 0777),
+			})
+			if err != nil {
+				return err
+			}
+			if err := fetchGitBlob(ctx, c, contentURL, tw); err != nil {
+				return err
+			}
+		}
+
+		if tree.Entries.Cursor == nil {
+			return nil
+		}
+
+		entry, err := getGitTreeEntry(ctx, c, name, owner, rev, path, tree.Entries.Cursor)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		tree, ok = entry.Object.Value.(*gitsrht.Tree)
+		if !ok {
+			return fmt.Errorf("%q is not a tree", path)
+		}
+	}
+}
+
+// getGitTreeEntry returns the tree entry at path for the given revision. If
+// path is empty, the root tree is returned.
+func getGitTreeEntry(ctx context.Context, c *Client, name, owner, rev, path string, cursor *gitsrht.Cursor) (*gitsrht.TreeEntry, error) {
+	var (
+		user     *gitsrht.User
+		username string
+		err      error
+	)
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	path = strings.Trim(path, "/")
+	switch {
+	case path == "" && username != "":
+		user, err = gitsrht.TreeByUser(c.Client, ctx, username, name, rev, cursor)
+	case path == "":
+		user, err = gitsrht.TreeByRepoName(c.Client, ctx, name, rev, cursor)
+	case username != "":
+		user, err = gitsrht.PathByUser(c.Client, ctx, username, name, rev, path, cursor)
+	default:
+		user, err = gitsrht.PathByRepoName(c.Client, ctx, name, rev, path, cursor)
+	}
+
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, fmt.Errorf("no such user %q", username)
+	} else if user.Repository == nil {
+		return nil, fmt.Errorf("no such repository %q", name)
+	}
+
+	if path == "" {
+		commit := user.Repository.Revparse_single
+		if commit == nil {
+			return nil, fmt.Errorf("no such revision %q", rev)
+		}
+		return &gitsrht.TreeEntry{
+			Mode:   040000,
+			Object: &gitsrht.Object{Type: gitsrht.ObjectTypeTree, Value: commit.Tree},
+		}, nil
+	}
+
+	if user.Repository.Path == nil {
+		return nil, fmt.Errorf("no such path %q at revision %q", path, rev)
+	}
+	return user.Repository.Path, nil
+}
+
+func gitBlob(obj *gitsrht.Object) (contentURL string, size int32, ok bool) {
+	switch blob := obj.Value.(type) {
+	case *gitsrht.TextBlob:
+		return string(blob.Content), blob.Size, true
+	case *gitsrht.BinaryBlob:
+		return string(blob.Content), blob.Size, true
+	default:
+		return "", 0, false
+	}
+}
+
+func fetchGitBlob(ctx context.Context, c *Client, contentURL string, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, contentURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("invalid HTTP status: %v", resp.Status)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to copy response body: %v", err)
+	}
+	return nil
+}
+
+func newGitRefsCommand() *cobra.Command {
+	var tags, branches bool
+	var count int
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		var name, owner, instance string
+		if len(args) > 0 {
+			name, owner, instance = parseResourceName(args[0])
+		} else {
+			var err error
+			name, owner, instance, err = getGitRepoName(ctx, cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClientWithInstance("git", cmd, instance)
+
+		var prefix string
+		if tags {
+			prefix = "refs/tags/"
+		} else if branches {
+			prefix = "refs/heads/"
+		}
+
+		var (
+			cursor   *gitsrht.Cursor
+			username string
+			printed  int
+		)
+		if owner != "" {
+			username = strings.TrimLeft(owner, ownerPrefixes)
+		}
+
+		err := pagerify(func(p pager) error {
+			var (
+				user *gitsrht.User
+				err  error
+			)
+			if username != "" {
+				user, err = gitsrht.ReferencesByUser(c.Client, ctx, username, name, cursor)
+			} else {
+				user, err = gitsrht.ReferencesByRepoName(c.Client, ctx, name, cursor)
+			}
+
+			if err != nil {
+				return err
+			} else if user == nil {
+				return errors.New("no such user")
+			} else if user.Repository == nil {
+				return fmt.Errorf("no such repository %q", name)
+			}
+
+			var head string
+			if user.Repository.HEAD != nil {
+				head = user.Repository.HEAD.Name
+			}
+
+			// References are filtered on the client, only count the printed
+			// ones
+			refs := user.Repository.References
+			var n int
+			for _, ref := range refs.Results {
+				if count > 0 && printed+n >= count {
+					break
+				}
+				if !strings.HasPrefix(ref.Name, prefix) {
+					continue
+				}
+				printGitReference(p, &ref, ref.Name == head)
+				n++
+			}
+			printed += n
+
+			cursor = refs.Cursor
+			if p.IsDone(cursor, n) {
+				return pagerDone
+			}
+
+			return nil
+		}, count)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "refs [repo]",
+		Short:             "List references",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeGitRepo,
+		Run:               run,
+	}
+	cmd.Flags().BoolVar(&tags, "tags", false, "only list tags")
+	cmd.Flags().BoolVar(&branches, "branches", false, "only list branches")
+	cmd.MarkFlagsMutuallyExclusive("tags", "branches")
+	cmd.Flags().IntVar(&count, "count", 0, "number of references to fetch")
+	cmd.RegisterFlagCompletionFunc("count", cobra.NoFileCompletions)
+	return cmd
+}
+
+func printGitReference(w io.Writer, ref *gitsrht.Reference, head bool) {
+	s := termfmt.Bold.String(ref.Name)
+	if head {
+		s += termfmt.Blue.String(" (HEAD)")
+	}
+
+	if ref.Follow == nil {
+		s += fmt.Sprintf(" %s\n", termfmt.Yellow.String(ref.Target))
+		fmt.Fprint(w, s)
+		return
+	}
+
+	switch obj := ref.Follow.Value.(type) {
+	case *gitsrht.Tag:
+		s += fmt.Sprintf(" %s %s", termfmt.Yellow.String(obj.ShortId), termfmt.Dim.String("tag"))
+		if obj.Target != nil {
+			s += fmt.Sprintf(" -> %s %s", termfmt.Yellow.String(obj.Target.ShortId),
+				termfmt.Dim.String(strings.ToLower(string(obj.Target.Type))))
+		}
+		s += "\n"
+		if obj.Tagger != nil {
+			s += fmt.Sprintf("  Tagger: %s <%s> (%s)\n", obj.Tagger.Name, obj.Tagger.Email,
+				humanize.Time(obj.Tagger.Time.Time))
+		}
+		if message := strings.TrimSpace(obj.Message); message != "" {
+			s += indent(message, "    ") + "\n"
+		}
+	case *gitsrht.Commit:
+		subject, _, _ := strings.Cut(obj.Message, "\n")
+		s += fmt.Sprintf(" %s %s %s\n", termfmt.Yellow.String(obj.ShortId), termfmt.Dim.String("commit"), subject)
+	default:
+		s += fmt.Sprintf(" %s %s\n", termfmt.Yellow.String(ref.Follow.ShortId),
+			termfmt.Dim.String(strings.ToLower(string(ref.Follow.Type))))
+	}
+
+	if ref.Artifacts != nil && len(ref.Artifacts.Results) > 0 {
+		s += "  Artifacts:\n"
+		for _, artifact := range ref.Artifacts.Results {
+			s += fmt.Sprintf("    %s %s (%s)\n", termfmt.DarkYellow.Sprintf("#%d", artifact.Id),
+				artifact.Filename, humanize.Bytes(uint64(artifact.Size)))
+		}
+	}
+
+	fmt.Fprint(w, s)
+}
+
+func newGitReleaseCommand() *cobra.Command {
+	var message, remote string
+	var noSign, shortlog bool
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		tag := args[0]
+
+		repoName, owner, instance, err := getGitRepoName(ctx, cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		c := createClientWithInstance("git", cmd, instance)
+		c.HTTP.Timeout = fileTransferTimeout
+		repoID, err := getGitRepoID(c, ctx, repoName, owner)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// Open all files before doing anything irreversible
+		var files []*os.File
+		for _, filename := range args[1:] {
+			f, err := os.Open(filename)
+			if err != nil {
+				log.Fatalf("failed to open input file: %v", err)
+			}
+			defer f.Close()
+			files = append(files, f)
+		}
+
+		if message == "" {
+			message = fmt.Sprintf("%s %s", repoName, tag)
+			if isStdinTerminal {
+				message, err = getInputWithEditor("hut_tag*.txt", message+"\n")
+				if err != nil {
+					log.Fatal(err)
+				}
+				message = strings.TrimSpace(message)
+				if message == "" {
+					log.Println("Aborting due to empty tag message.")
+					os.Exit(1)
+				}
+			}
+		}
+
+		tagArgs := []string{"tag", "-a", "-m", message}
+		if !noSign {
+			tagArgs = append(tagArgs, "-s")
+		}
+		tagArgs = append(tagArgs, tag)
+		if err := runGitCommand(tagArgs...); err != nil {
+			log.Fatalf("failed to create tag: %v", err)
+		}
+
+		if err := runGitCommand("push", remote, "refs/tags/"+tag); err != nil {
+			log.Fatalf("failed to push tag: %v", err)
+		}
+
+		if err := waitGitReference(ctx, c, repoName, owner, "refs/tags/"+tag); err != nil {
 			log.Fatal(err)
 		}
+		log.Printf("Pushed tag %q\n", tag)
 
-		acl, err := gitsrht.DeleteACL(c.Client, ctx, id)
-		if err != nil {
-			log.Fatal(err)
-		} else if acl == nil {
-			log.Fatalf("failed to delete ACL entry with ID %d", id)
+		for _, f := range files {
+			file := gqlclient.Upload{Filename: filepath.Base(f.Name()), Body: f}
+			artifact, err := gitsrht.UploadArtifact(c.Client, ctx, repoID, tag, file)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			log.Printf("Uploaded %q\n", artifact.Filename)
 		}
 
-		log.Printf("Deleted ACL entry for %q in repository %q\n", acl.Entity.CanonicalName, acl.Repository.Name)
+		if shortlog {
+			var prevCommit string
+			out, err := exec.Command("git", "describe", "--abbrev=0", tag+"^").Output()
+			if err == nil {
+				prevTag := strings.TrimSpace(string(out))
+				out, err = exec.Command("git", "rev-list", "-n", "1", prevTag).Output()
+				if err != nil {
+					log.Fatalf("failed to resolve previous tag %q: %v", prevTag, err)
+				}
+				prevCommit = strings.TrimSpace(string(out))
+			}
+
+			commits, err := gitLogRange(ctx, c, repoName, owner, tag, prevCommit)
+			if err != nil {
+				log.Fatalf("failed to generate shortlog: %v", err)
+			}
+
+			fmt.Print(formatShortlog(commits))
+		}
 	}
 
 	cmd := &cobra.Command{
-		Use:               "delete <ID>",
-		Short:             "Delete an ACL entry",
-		Args:              cobra.ExactArgs(1),
-		ValidArgsFunction: cobra.NoFileCompletions,
-		Run:               run,
+		Use:   "release <tag> [filename...]",
+		Short: "Tag a release and upload artifacts",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   run,
 	}
+	cmd.Flags().StringVarP(&message, "message", "m", "", "tag message")
+	cmd.RegisterFlagCompletionFunc("message", cobra.NoFileCompletions)
+	cmd.Flags().StringVar(&remote, "remote", "origin", "remote to push the tag to")
+	cmd.RegisterFlagCompletionFunc("remote", cobra.NoFileCompletions)
+	cmd.Flags().BoolVar(&noSign, "no-sign", false, "do not sign the tag")
+	cmd.Flags().BoolVar(&shortlog, "shortlog", false, "print a shortlog since the previous tag")
 	return cmd
 }
 
-func newGitShowCommand() *cobra.Command {
-	var web bool
-	run := func(cmd *cobra.Command, args []string) {
-		ctx := cmd.Context()
+func runGitCommand(args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
 
-		var name, owner, instance string
-		if len(args) > 0 {
-			name, owner, instance = parseResourceName(args[0])
-		} else {
-			var err error
-			name, owner, instance, err = getGitRepoName(ctx, cmd)
-			if err != nil {
-				log.Fatal(err)
-			}
-		}
+// waitGitReference waits until the reference appears in the repository,
+// since pushed references are not visible to the API immediately.
+func waitGitReference(ctx context.Context, c *Client, repoName, owner, ref string) error {
+	const timeout = time.Minute
 
-		c := createClientWithInstance("git", cmd, instance)
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
 
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
 		var (
-			user     *gitsrht.User
-			username string
-			err      error
+			user *gitsrht.User
+			err  error
 		)
-		if owner == "" {
-			user, err = gitsrht.RepositoryByName(c.Client, ctx, name)
+		if username != "" {
+			user, err = gitsrht.ReferenceByUser(c.Client, ctx, username, repoName, ref)
 		} else {
-			username = strings.TrimLeft(owner, ownerPrefixes)
-			user, err = gitsrht.RepositoryByUser(c.Client, ctx, username, name)
+			user, err = gitsrht.ReferenceByRepoName(c.Client, ctx, repoName, ref)
 		}
 		if err != nil {
-			log.Fatal(err)
-		} else if user == nil {
-			log.Fatalf("no such user %q", username)
-		} else if user.Repository == nil {
-			log.Fatalf("no such repository %q", name)
+			return fmt.Errorf("failed to get reference %q: %v", ref, err)
+		} else if user == nil || user.Repository == nil {
+			return fmt.Errorf("no such repository %q", repoName)
+		} else if user.Repository.Reference != nil {
+			return nil
 		}
-		repo := user.Repository
-		repoUrl := fmt.Sprintf("%s/%s/%s", c.BaseURL, owner, name)
 
-		if web {
-			err := openURL(repoUrl)
-			if err != nil {
-				log.Fatal(err)
-			}
-			os.Exit(0)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("reference %q did not appear within %v", ref, timeout)
+		case <-ticker.C:
+			// Continue looping
 		}
+	}
+}
 
-		// prints url for easy access
-		fmt.Printf("%s\n\n", repoUrl)
+// gitLogRange returns the commits reachable from rev but not from the commit
+// with the ID exclude, like "git log exclude..rev". The repository log lists
+// children before their parents, so commits reachable from exclude are
+// marked as the log is walked, which also handles merges.
+func gitLogRange(ctx context.Context, c *Client, repoName, owner, rev, exclude string) ([]gitsrht.Commit, error) {
+	var (
+		commits  []gitsrht.Commit
+		cursor   *gitsrht.Cursor
+		username string
+	)
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
 
-		// prints basic information
-		fmt.Printf("%s (%s)\n", termfmt.Bold.String(repo.Name), repo.Visibility.TermString())
-		if repo.Description != nil && *repo.Description != "" {
-			fmt.Printf("  %s\n", *repo.Description)
+	walk := newGitLogRangeWalk(exclude)
+	for {
+		var (
+			user *gitsrht.User
+			err  error
+		)
+		if username != "" {
+			user, err = gitsrht.LogByUser(c.Client, ctx, username, repoName, &rev, cursor)
+		} else {
+			user, err = gitsrht.LogByRepoName(c.Client, ctx, repoName, &rev, cursor)
+		}
+		if err != nil {
+			return nil, err
+		} else if user == nil || user.Repository == nil {
+			return nil, fmt.Errorf("no such repository %q", repoName)
 		}
 
-		// prints latest tag
-		tags := repo.References.Tags()
-		if len(tags) > 0 {
-			fmt.Println()
-			fmt.Printf("  Latest tag: %s\n", tags[len(tags)-1])
+		included, done := walk.next(user.Repository.Log.Results)
+		commits = append(commits, included...)
+		if done {
+			return commits, nil
 		}
 
-		// prints branches
-		branches := repo.References.Heads()
-		if len(branches) > 0 {
-			fmt.Println()
-			fmt.Printf("  Branches:\n")
-			for i := 0; i < len(branches); i++ {
-				fmt.Printf("    %s\n", branches[i])
-			}
+		cursor = user.Repository.Log.Cursor
+		if cursor == nil {
+			return commits, nil
 		}
+	}
+}
 
-		// prints the three most recent commits
-		if len(repo.Log.Results) >= 3 {
-			fmt.Println()
-			fmt.Printf("  Recent log:\n")
+// gitLogRangeWalk filters a repository log down to the commits which are not
+// reachable from an excluded commit.
+type gitLogRangeWalk struct {
+	excluded map[string]bool // commits reachable from the excluded commit
+	pending  map[string]bool // parents of included commits not seen yet
+}
 
-			for _, commit := range repo.Log.Results[:3] {
-				fmt.Printf("    %s %s <%s> (%s)\n",
-					termfmt.Yellow.Sprintf("%s", commit.ShortId),
-					commit.Author.Name,
-					commit.Author.Email,
-					humanize.Time(commit.Author.Time.Time))
+func newGitLogRangeWalk(exclude string) *gitLogRangeWalk {
+	walk := &gitLogRangeWalk{
+		excluded: make(map[string]bool),
+		pending:  make(map[string]bool),
+	}
+	if exclude != "" {
+		walk.excluded[exclude] = true
+	}
+	return walk
+}
 
-				commitLines := strings.Split(commit.Message, "\n")
-				fmt.Printf("      %s\n", commitLines[0])
+// next processes a page of the log, which lists children before their
+// parents, and returns the commits to include. done is set once all remaining
+// commits are reachable from the excluded commit.
+func (walk *gitLogRangeWalk) next(page []gitsrht.Commit) (included []gitsrht.Commit, done bool) {
+	for _, commit := range page {
+		delete(walk.pending, commit.Id)
+		if walk.excluded[commit.Id] {
+			for _, parent := range commit.Parents {
+				walk.excluded[parent.Id] = true
+				delete(walk.pending, parent.Id)
+			}
+		} else {
+			included = append(included, commit)
+			for _, parent := range commit.Parents {
+				if !walk.excluded[parent.Id] {
+					walk.pending[parent.Id] = true
+				}
 			}
 		}
+
+		if len(walk.pending) == 0 {
+			return included, true
+		}
 	}
+	return included, false
+}
 
-	cmd := &cobra.Command{
-		Use:               "show [repo]",
-		Short:             "Shows a repository",
-		Args:              cobra.MaximumNArgs(1),
-		ValidArgsFunction: completeGitRepo,
-		Run:               run,
+// formatShortlog groups commits by author like git-shortlog(1). Commits are
+// expected newest first.
+func formatShortlog(commits []gitsrht.Commit) string {
+	subjects := make(map[string][]string)
+	var authors []string
+	for i := len(commits) - 1; i >= 0; i-- {
+		name := commits[i].Author.Name
+		if _, ok := subjects[name]; !ok {
+			authors = append(authors, name)
+		}
+		subject, _, _ := strings.Cut(commits[i].Message, "\n")
+		subjects[name] = append(subjects[name], subject)
 	}
-	cmd.Flags().BoolVar(&web, "web", false, "open in browser")
+	slices.Sort(authors)
 
-	return cmd
+	var sb strings.Builder
+	for _, author := range authors {
+		fmt.Fprintf(&sb, "%s (%d):\n", author, len(subjects[author]))
+		for _, subject := range subjects[author] {
+			fmt.Fprintf(&sb, "      %s\n", subject)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func printGitCommitOneline(w io.Writer, commit *gitsrht.Commit) {
+	subject, _, _ := strings.Cut(commit.Message, "\n")
+	fmt.Fprintf(w, "%s %s\n", termfmt.Yellow.String(commit.ShortId), subject)
+}
+
+func printGitCommit(w io.Writer, commit *gitsrht.Commit) {
+	fmt.Fprintln(w, termfmt.Yellow.Sprintf("commit %s", commit.Id))
+	if len(commit.Parents) > 1 {
+		var parents []string
+		for _, parent := range commit.Parents {
+			parents = append(parents, parent.ShortId)
+		}
+		fmt.Fprintf(w, "Merge: %s\n", strings.Join(parents, " "))
+	}
+	fmt.Fprintf(w, "Author: %s <%s>\n", commit.Author.Name, commit.Author.Email)
+	if commit.Committer.Name != commit.Author.Name || commit.Committer.Email != commit.Author.Email {
+		fmt.Fprintf(w, "Commit: %s <%s>\n", commit.Committer.Name, commit.Committer.Email)
+	}
+	fmt.Fprintf(w, "Date:   %s\n", commit.Author.Time.Format(dateLayout))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, indent(strings.TrimRight(commit.Message, "\n"), "    "))
+	fmt.Fprintln(w)
+}
+
+func printGitDiff(w io.Writer, diff string) {
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "),
+			strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "):
+			line = termfmt.Bold.String(line)
+		case strings.HasPrefix(line, "@@"):
+			line = termfmt.Blue.String(line)
+		case strings.HasPrefix(line, "+"):
+			line = termfmt.Green.String(line)
+		case strings.HasPrefix(line, "-"):
+			line = termfmt.Red.String(line)
+		}
+		fmt.Fprintln(w, line)
+	}
 }
 
 func newGitUserWebhookCommand() *cobra.Command {
@@ -795,16 +2413,16 @@ func newGitUserWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newGitUserWebhookCreateCommand())
 	cmd.AddCommand(newGitUserWebhookListCommand())
 	cmd.AddCommand(newGitUserWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadGitUserWebhookDeliveries, loadGitUserWebhookSample, nil)
 	return cmd
 }
 
 func newGitUserWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		c := createClient("git", cmd)
 
 		var config gitsrht.UserWebhookInput
 		config.Url = url
@@ -815,6 +2433,19 @@ func newGitUserWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("git", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("git", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClient("git", cmd)
 
 		webhook, err := gitsrht.CreateUserWebhook(c.Client, ctx, config)
 		if err != nil {
@@ -840,7 +2471,10 @@ func newGitUserWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -912,27 +2546,92 @@ func newGitUserWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadGitUserWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	c := createClient("git", cmd)
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		webhook, err := gitsrht.UserWebhookDeliveries(c.Client, ctx, id, (*gitsrht.Cursor)(cursor))
+		if err != nil {
+			return nil, nil, err
+		} else if webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertGitWebhookDeliveries(webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadGitUserWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	c := createClient("git", cmd)
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		webhook, err := gitsrht.UserWebhookSample(c.Client, ctx, id, gitsrht.WebhookEvent(event))
+		if err != nil {
+			return "", err
+		} else if webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return webhook.Sample, nil
+	}
+}
+
+func loadGitWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	c := createClient("git", cmd)
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		webhook, err := gitsrht.GitWebhookDeliveries(c.Client, ctx, id, (*gitsrht.Cursor)(cursor))
+		if err != nil {
+			return nil, nil, err
+		} else if webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertGitWebhookDeliveries(webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadGitWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	c := createClient("git", cmd)
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		webhook, err := gitsrht.GitWebhookSample(c.Client, ctx, id, gitsrht.WebhookEvent(event))
+		if err != nil {
+			return "", err
+		} else if webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return webhook.Sample, nil
+	}
+}
+
+func convertGitWebhookDeliveries(cursor *gitsrht.WebhookDeliveryCursor) ([]webhookDelivery, *string) {
+	var deliveries []webhookDelivery
+	for _, delivery := range cursor.Results {
+		deliveries = append(deliveries, webhookDelivery{
+			UUID:            delivery.Uuid,
+			Date:            delivery.Date.Time,
+			Event:           string(delivery.Event),
+			RequestBody:     delivery.RequestBody,
+			ResponseBody:    delivery.ResponseBody,
+			ResponseHeaders: delivery.ResponseHeaders,
+			ResponseStatus:  delivery.ResponseStatus,
+		})
+	}
+	return deliveries, (*string)(cursor.Cursor)
+}
+
 func newGitUpdateCommand() *cobra.Command {
-	var visibility, branch, readme, description, newName string
+	var visibility, branch, readme, description, newName, match string
+	var all, autoConfirm bool
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 
-		var name, owner, instance string
-		if len(args) > 0 {
-			name, owner, instance = parseResourceName(args[0])
-		} else {
-			var err error
-			name, owner, instance, err = getGitRepoName(ctx, cmd)
-			if err != nil {
-				log.Fatal(err)
-			}
+		bulk := match != "" || all
+		if bulk && len(args) > 0 {
+			log.Fatal("--match and --all can't be used with a repository argument")
+		} else if bulk && newName != "" {
+			log.Fatal("--name can't be used with --match or --all")
 		}
+		checkRepoPattern(match)
 
-		c := createClientWithInstance("git", cmd, instance)
-		id, err := getGitRepoID(c, ctx, name, owner)
-		if err != nil {
-			log.Fatal(err)
-		}
 		var input gitsrht.RepoInput
 
 		if visibility != "" {
@@ -943,12 +2642,10 @@ func newGitUpdateCommand() *cobra.Command {
 			input.Visibility = &repoVisibility
 		}
 
+		clearDescription := false
 		if cmd.Flags().Changed("description") {
 			if description == "" {
-				_, err := gitsrht.ClearDescription(c.Client, ctx, id)
-				if err != nil {
-					log.Fatalf("failed to clear description: %v", err)
-				}
+				clearDescription = true
 			} else {
 				input.Description = &description
 			}
@@ -962,11 +2659,9 @@ func newGitUpdateCommand() *cobra.Command {
 			input.Name = &newName
 		}
 
+		clearReadme := false
 		if readme == "" && cmd.Flags().Changed("readme") {
-			_, err := gitsrht.ClearCustomReadme(c.Client, ctx, id)
-			if err != nil {
-				log.Fatalf("failed to unset custom README: %v", err)
-			}
+			clearReadme = true
 		} else if readme != "" {
 			var (
 				b   []byte
@@ -986,7 +2681,52 @@ func newGitUpdateCommand() *cobra.Command {
 			input.Readme = &s
 		}
 
-		repo, err := gitsrht.UpdateRepository(c.Client, ctx, id, input)
+		update := func(c *Client, id int32) (*gitsrht.Repository, error) {
+			if clearDescription {
+				if _, err := gitsrht.ClearDescription(c.Client, ctx, id); err != nil {
+					return nil, fmt.Errorf("failed to clear description: %v", err)
+				}
+			}
+			if clearReadme {
+				if _, err := gitsrht.ClearCustomReadme(c.Client, ctx, id); err != nil {
+					return nil, fmt.Errorf("failed to unset custom README: %v", err)
+				}
+			}
+			return gitsrht.UpdateRepository(c.Client, ctx, id, input)
+		}
+
+		if bulk {
+			c := createClient("git", cmd)
+			repos, err := getGitReposMatching(c, ctx, match)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			runRepoBulk("update", repos, autoConfirm, func(repo bulkRepo) error {
+				_, err := update(c, repo.ID)
+				return err
+			})
+			return
+		}
+
+		var name, owner, instance string
+		if len(args) > 0 {
+			name, owner, instance = parseResourceName(args[0])
+		} else {
+			var err error
+			name, owner, instance, err = getGitRepoName(ctx, cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClientWithInstance("git", cmd, instance)
+		id, err := getGitRepoID(c, ctx, name, owner)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		repo, err := update(c, id)
 		if err != nil {
 			log.Fatal(err)
 		} else if repo == nil {
@@ -1011,6 +2751,11 @@ func newGitUpdateCommand() *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("description", cobra.NoFileCompletions)
 	cmd.Flags().StringVarP(&newName, "name", "n", "", "repository name")
 	cmd.RegisterFlagCompletionFunc("name", cobra.NoFileCompletions)
+	cmd.Flags().StringVar(&match, "match", "", "update all repositories matching a glob pattern")
+	cmd.RegisterFlagCompletionFunc("match", cobra.NoFileCompletions)
+	cmd.Flags().BoolVar(&all, "all", false, "update all repositories")
+	cmd.MarkFlagsMutuallyExclusive("match", "all")
+	cmd.Flags().BoolVarP(&autoConfirm, "yes", "y", false, "auto confirm")
 	return cmd
 }
 
@@ -1022,12 +2767,13 @@ func newGitWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newGitWebhookCreateCommand())
 	cmd.AddCommand(newGitWebhookListCommand())
 	cmd.AddCommand(newGitWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadGitWebhookDeliveries, loadGitWebhookSample, nil)
 	return cmd
 }
 
 func newGitWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
@@ -1059,6 +2805,17 @@ func newGitWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("git", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("git", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
 
 		webhook, err := gitsrht.CreateGitWebhook(c.Client, ctx, config)
 		if err != nil {
@@ -1084,7 +2841,10 @@ func newGitWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -1242,6 +3002,32 @@ func getGitRepoID(c *Client, ctx context.Context, name, owner string) (int32, er
 	return user.Repository.Id, nil
 }
 
+// getGitReposMatching returns the repositories of the current user whose
+// name matches a glob pattern.
+func getGitReposMatching(c *Client, ctx context.Context, pattern string) ([]bulkRepo, error) {
+	var (
+		repos  []bulkRepo
+		cursor *gitsrht.Cursor
+	)
+	for {
+		repositories, err := gitsrht.Repositories(c.Client, ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repo := range repositories.Results {
+			if matchRepoName(pattern, repo.Name) {
+				repos = append(repos, bulkRepo{ID: repo.Id, Name: repo.Name})
+			}
+		}
+
+		cursor = repositories.Cursor
+		if cursor == nil {
+			return repos, nil
+		}
+	}
+}
+
 func gitRemoteURLs(ctx context.Context) ([]*url.URL, error) {
 	var urls []*url.URL
 
@@ -1351,9 +3137,9 @@ func completeArtifacts(cmd *cobra.Command, args []string, toComplete string) ([]
 
 	if owner != "" {
 		username := strings.TrimLeft(owner, ownerPrefixes)
-		user, err = gitsrht.ListArtifactsByUser(c.Client, ctx, username, repoName)
+		user, err = gitsrht.ListArtifactsByUser(c.Client, ctx, username, repoName, nil)
 	} else {
-		user, err = gitsrht.ListArtifacts(c.Client, ctx, repoName)
+		user, err = gitsrht.ListArtifacts(c.Client, ctx, repoName, nil)
 	}
 
 	if err != nil || user == nil || user.Repository == nil {
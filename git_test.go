@@ -0,0 +1,244 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~xenrox/hut/srht/gitsrht"
+)
+
+func TestIsGitCloneURL(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"https://github.com/emersion/hut", true},
+		{"git://git.example.org/hut.git", true},
+		{"git@github.com:emersion/hut.git", true},
+		{"example.org:hut", true},
+		{"hut", false},
+		{"~emersion/hut", false},
+		{"git.sr.ht/~emersion/hut", false},
+		{"./dir:name", false},
+		{":hut", false},
+		{"file:///srv/git/hut.git", true},
+		{"ssh://git@example.org/hut", true},
+		{dir, true},
+		{filepath.Join(dir, "missing"), false},
+	}
+
+	for _, test := range tests {
+		got := isGitCloneURL(test.s)
+		if got != test.want {
+			t.Errorf("isGitCloneURL(%q): expected %v, got %v", test.s, test.want, got)
+		}
+	}
+}
+
+func TestGitCloneURLRepoName(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"https://github.com/emersion/hut", "hut"},
+		{"https://github.com/emersion/hut.git/", "hut"},
+		{"git@github.com:emersion/hut.git", "hut"},
+		{"example.org:hut", "hut"},
+	}
+
+	for _, test := range tests {
+		got := gitCloneURLRepoName(test.s)
+		if got != test.want {
+			t.Errorf("gitCloneURLRepoName(%q): expected %q, got %q", test.s, test.want, got)
+		}
+	}
+}
+
+func testCommit(id, author, message string, parents ...string) gitsrht.Commit {
+	commit := gitsrht.Commit{
+		Id:      id,
+		Message: message,
+		Author:  &gitsrht.Signature{Name: author},
+	}
+	for _, parent := range parents {
+		commit.Parents = append(commit.Parents, gitsrht.Commit{Id: parent})
+	}
+	return commit
+}
+
+func TestFormatShortlog(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []gitsrht.Commit
+		want    string
+	}{
+		{"empty", nil, ""},
+		{
+			"single author",
+			[]gitsrht.Commit{
+				testCommit("b", "Alice", "Fix bar\n\nSome details\n"),
+				testCommit("a", "Alice", "Add foo\n"),
+			},
+			"Alice (2):\n      Add foo\n      Fix bar\n\n",
+		},
+		{
+			"sorted authors",
+			[]gitsrht.Commit{
+				testCommit("c", "Alice", "Third"),
+				testCommit("b", "Bob", "Second"),
+				testCommit("a", "Alice", "First"),
+			},
+			"Alice (2):\n      First\n      Third\n\nBob (1):\n      Second\n\n",
+		},
+	}
+
+	for _, test := range tests {
+		got := formatShortlog(test.commits)
+		if got != test.want {
+			t.Errorf("formatShortlog(%s): expected %q, got %q", test.name, test.want, got)
+		}
+	}
+}
+
+func TestGitLogRangeWalk(t *testing.T) {
+	tests := []struct {
+		name    string
+		exclude string
+		pages   [][]gitsrht.Commit
+		want    []string
+	}{
+		{
+			name:    "linear",
+			exclude: "b",
+			pages: [][]gitsrht.Commit{{
+				testCommit("d", "", "", "c"),
+				testCommit("c", "", "", "b"),
+				testCommit("b", "", "", "a"),
+				testCommit("a", "", ""),
+			}},
+			want: []string{"d", "c"},
+		},
+		{
+			name:    "no exclude",
+			exclude: "",
+			pages: [][]gitsrht.Commit{
+				{testCommit("b", "", "", "a")},
+				{testCommit("a", "", "")},
+			},
+			want: []string{"b", "a"},
+		},
+		{
+			name:    "paged",
+			exclude: "a",
+			pages: [][]gitsrht.Commit{
+				{testCommit("c", "", "", "b")},
+				{testCommit("b", "", "", "a")},
+				{testCommit("a", "", "")},
+			},
+			want: []string{"c", "b"},
+		},
+		{
+			// m merges side branch s (forked from a) into b
+			name:    "merge",
+			exclude: "b",
+			pages: [][]gitsrht.Commit{{
+				testCommit("m", "", "", "b", "s"),
+				testCommit("s", "", "", "a"),
+				testCommit("b", "", "", "a"),
+				testCommit("a", "", ""),
+			}},
+			want: []string{"m", "s"},
+		},
+		{
+			// a side branch merged before exclude is reachable from it
+			name:    "merged before exclude",
+			exclude: "m",
+			pages: [][]gitsrht.Commit{{
+				testCommit("c", "", "", "m"),
+				testCommit("m", "", "", "b", "s"),
+				testCommit("s", "", "", "a"),
+				testCommit("b", "", "", "a"),
+				testCommit("a", "", ""),
+			}},
+			want: []string{"c"},
+		},
+		{
+			name:    "exclude is rev",
+			exclude: "b",
+			pages: [][]gitsrht.Commit{{
+				testCommit("b", "", "", "a"),
+				testCommit("a", "", ""),
+			}},
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		walk := newGitLogRangeWalk(test.exclude)
+		var got []string
+		for _, page := range test.pages {
+			included, done := walk.next(page)
+			for _, commit := range included {
+				got = append(got, commit.Id)
+			}
+			if done {
+				break
+			}
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "out")
+
+	errFail := errors.New("fail")
+	err := writeFileAtomic(filename, func(w io.Writer) error {
+		io.WriteString(w, "partial")
+		return errFail
+	})
+	if err != errFail {
+		t.Errorf("writeFileAtomic: expected %v, got %v", errFail, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("writeFileAtomic: expected no file after failure, got %v", entries)
+	}
+
+	err = writeFileAtomic(filename, func(w io.Writer) error {
+		_, err := io.WriteString(w, "content")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("writeFileAtomic: %v", err)
+	}
+	if b, err := os.ReadFile(filename); err != nil || string(b) != "content" {
+		t.Errorf("writeFileAtomic: expected %q, got %q (%v)", "content", b, err)
+	}
+}
+
+func TestGitMirrorCacheName(t *testing.T) {
+	a := gitMirrorCacheName("https://github.com/emersion/hut.git")
+	if !strings.HasPrefix(a, "hut-") || !strings.HasSuffix(a, ".git") {
+		t.Errorf("gitMirrorCacheName: unexpected name %q", a)
+	}
+
+	for _, source := range []string{
+		"https://github.com/emersion/hut",
+		"https://gitlab.com/emersion/hut.git",
+		"https://github.com/emersion_hut.git",
+	} {
+		if b := gitMirrorCacheName(source); b == a {
+			t.Errorf("gitMirrorCacheName(%q): collides with %q", source, a)
+		}
+	}
+}
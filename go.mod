@@ -9,7 +9,9 @@ require (
 	github.com/google/shlex v0.0.0-20191202100458-e7afc7fbc510
 	github.com/juju/ansiterm v1.0.0
 	github.com/spf13/cobra v1.9.1
+	github.com/vektah/gqlparser/v2 v2.5.8
 	golang.org/x/term v0.32.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -19,6 +21,5 @@ require (
 	github.com/lunixbochs/vtclean v1.0.0 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
 	github.com/stretchr/testify v1.7.0 // indirect
-	github.com/vektah/gqlparser/v2 v2.5.8 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 )
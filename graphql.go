@@ -25,12 +25,11 @@ const graphqlPrefill = `
 
 func newGraphqlCommand() *cobra.Command {
 	var stringVars, fileVars []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	run := func(cmd *cobra.Command, args []string) {
 		service := args[0]
 
 		ctx := cmd.Context()
-		c := createClient(service, cmd)
 
 		var query string
 		if stdin {
@@ -56,6 +55,30 @@ func newGraphqlCommand() *cobra.Command {
 			os.Exit(1)
 		}
 
+		if sample {
+			schema, doc, err := parseQuery(service, query)
+			if err != nil {
+				log.Fatal(err)
+			}
+			data, err := sampleQueryResponse(schema, doc, "")
+			if err != nil {
+				log.Fatal(err)
+			}
+			os.Stdout.Write(data)
+			return
+		} else if _, ok := serviceSchemas[service]; !ok && !noValidate {
+			// No schema is bundled for some services (e.g. hub), let the
+			// server validate the query
+			if debug, _ := cmd.Flags().GetBool("debug"); debug {
+				log.Printf("no bundled schema for %v.sr.ht, skipping query validation", service)
+			}
+		} else if !noValidate {
+			if _, _, err := parseQuery(service, query); err != nil {
+				log.Fatalf("%v\n(use --no-validate if the server schema is newer than the bundled one)", err)
+			}
+		}
+
+		c := createClient(service, cmd)
 		op := gqlclient.NewOperation(query)
 
 		for _, kv := range stringVars {
@@ -99,6 +122,9 @@ func newGraphqlCommand() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&stringVars, "var", "v", nil, "set string variable")
 	cmd.Flags().StringSliceVar(&fileVars, "file", nil, "set file variable")
 	cmd.Flags().BoolVar(&stdin, "stdin", !isStdinTerminal, "read query from stdin")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print a sample response instead of executing the query")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the query against the bundled schema")
+	cmd.MarkFlagsMutuallyExclusive("sample", "no-validate")
 	// TODO: JSON variable
 	return cmd
 }
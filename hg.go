@@ -208,35 +208,23 @@ func newHgDeleteCommand() *cobra.Command {
 }
 
 func newHgUpdateCommand() *cobra.Command {
-	var description, nonPublishing, readme, visibility string
+	var description, nonPublishing, readme, visibility, match string
+	var all, autoConfirm bool
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 
-		var name, owner, instance string
-		if len(args) > 0 {
-			name, owner, instance = parseResourceName(args[0])
-		} else {
-			var err error
-			name, owner, instance, err = getHgRepoName(ctx, cmd)
-			if err != nil {
-				log.Fatal(err)
-			}
-		}
-
-		c := createClientWithInstance("hg", cmd, instance)
-		id, err := getHgRepoID(c, ctx, name, owner)
-		if err != nil {
-			log.Fatal(err)
+		bulk := match != "" || all
+		if bulk && len(args) > 0 {
+			log.Fatal("--match and --all can't be used with a repository argument")
 		}
+		checkRepoPattern(match)
 
 		var input hgsrht.RepoInput
 
+		clearDescription := false
 		if cmd.Flags().Changed("description") {
 			if description == "" {
-				_, err := hgsrht.ClearDescription(c.Client, ctx, id)
-				if err != nil {
-					log.Fatalf("failed to clear description: %v", err)
-				}
+				clearDescription = true
 			} else {
 				input.Description = &description
 			}
@@ -250,11 +238,9 @@ func newHgUpdateCommand() *cobra.Command {
 			input.NonPublishing = &b
 		}
 
+		clearReadme := false
 		if readme == "" && cmd.Flags().Changed("readme") {
-			_, err := hgsrht.ClearCustomReadme(c.Client, ctx, id)
-			if err != nil {
-				log.Fatalf("failed to unset custom README: %v", err)
-			}
+			clearReadme = true
 		} else if readme != "" {
 			var (
 				b   []byte
@@ -282,7 +268,52 @@ func newHgUpdateCommand() *cobra.Command {
 			input.Visibility = &repoVisibility
 		}
 
-		repo, err := hgsrht.UpdateRepository(c.Client, ctx, id, input)
+		update := func(c *Client, id int32) (*hgsrht.Repository, error) {
+			if clearDescription {
+				if _, err := hgsrht.ClearDescription(c.Client, ctx, id); err != nil {
+					return nil, fmt.Errorf("failed to clear description: %v", err)
+				}
+			}
+			if clearReadme {
+				if _, err := hgsrht.ClearCustomReadme(c.Client, ctx, id); err != nil {
+					return nil, fmt.Errorf("failed to unset custom README: %v", err)
+				}
+			}
+			return hgsrht.UpdateRepository(c.Client, ctx, id, input)
+		}
+
+		if bulk {
+			c := createClient("hg", cmd)
+			repos, err := getHgReposMatching(c, ctx, match)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			runRepoBulk("update", repos, autoConfirm, func(repo bulkRepo) error {
+				_, err := update(c, repo.ID)
+				return err
+			})
+			return
+		}
+
+		var name, owner, instance string
+		if len(args) > 0 {
+			name, owner, instance = parseResourceName(args[0])
+		} else {
+			var err error
+			name, owner, instance, err = getHgRepoName(ctx, cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClientWithInstance("hg", cmd, instance)
+		id, err := getHgRepoID(c, ctx, name, owner)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		repo, err := update(c, id)
 		if err != nil {
 			log.Fatal(err)
 		} else if repo == nil {
@@ -305,6 +336,11 @@ func newHgUpdateCommand() *cobra.Command {
 	cmd.Flags().StringVar(&readme, "readme", "", "update the custom README")
 	cmd.Flags().StringVarP(&visibility, "visibility", "v", "", "repository visibility")
 	cmd.RegisterFlagCompletionFunc("visibility", completeVisibility)
+	cmd.Flags().StringVar(&match, "match", "", "update all repositories matching a glob pattern")
+	cmd.RegisterFlagCompletionFunc("match", cobra.NoFileCompletions)
+	cmd.Flags().BoolVar(&all, "all", false, "update all repositories")
+	cmd.MarkFlagsMutuallyExclusive("match", "all")
+	cmd.Flags().BoolVarP(&autoConfirm, "yes", "y", false, "auto confirm")
 	return cmd
 }
 
@@ -316,6 +352,7 @@ func newHgACLCommand() *cobra.Command {
 	cmd.AddCommand(newHgACLListCommand())
 	cmd.AddCommand(newHgACLUpdateCommand())
 	cmd.AddCommand(newHgACLDeleteCommand())
+	cmd.AddCommand(newHgACLApplyCommand())
 	return cmd
 }
 
@@ -475,6 +512,118 @@ func newHgACLDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func newHgACLApplyCommand() *cobra.Command {
+	var filename string
+	var prune, dryRun bool
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		resources, err := readACLFile(filename, "repo", aclModePermissions(func(s string) (string, error) {
+			mode, err := hgsrht.ParseAccessMode(s)
+			return string(mode), err
+		}))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, resource := range resources {
+			var name, owner, instance string
+			if resource.Name != "" {
+				name, owner, instance = parseResourceName(resource.Name)
+			} else if len(args) > 0 {
+				name, owner, instance = parseResourceName(args[0])
+			} else {
+				name, owner, instance, err = getHgRepoName(ctx, cmd)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			c := createClientWithInstance("hg", cmd, instance)
+			backend := newHgACLBackend(c, name, owner)
+			if err := applyACL(ctx, formatResourceName(name, owner), backend, resource.Entries, prune, dryRun); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "apply [repo]",
+		Short:             "Apply ACL entries from a file",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeHgRepo,
+		Run:               run,
+	}
+	cmd.Flags().StringVarP(&filename, "file", "f", "", "ACL file")
+	cmd.MarkFlagRequired("file")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete entries not listed in the file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the changes")
+	return cmd
+}
+
+func newHgACLBackend(c *Client, name, owner string) *aclBackend {
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	var repoID int32
+	return &aclBackend{
+		List: func(ctx context.Context) ([]aclEntry, error) {
+			var (
+				entries []aclEntry
+				cursor  *hgsrht.Cursor
+				user    *hgsrht.User
+				err     error
+			)
+			for {
+				if username != "" {
+					user, err = hgsrht.AclByUser(c.Client, ctx, username, name, cursor)
+				} else {
+					user, err = hgsrht.AclByRepoName(c.Client, ctx, name, cursor)
+				}
+
+				if err != nil {
+					return nil, err
+				} else if user == nil {
+					return nil, fmt.Errorf("no such user %q", username)
+				} else if user.Repository == nil {
+					return nil, fmt.Errorf("no such repository %q", name)
+				}
+
+				for _, acl := range user.Repository.AccessControlList.Results {
+					var permissions []string
+					if acl.Mode != nil {
+						permissions = []string{string(*acl.Mode)}
+					}
+					entries = append(entries, aclEntry{ID: acl.Id, Entity: acl.Entity.CanonicalName, Permissions: permissions})
+				}
+
+				cursor = user.Repository.AccessControlList.Cursor
+				if cursor == nil {
+					return entries, nil
+				}
+			}
+		},
+		Update: func(ctx context.Context, entity string, permissions []string) error {
+			if repoID == 0 {
+				id, err := getHgRepoID(c, ctx, name, owner)
+				if err != nil {
+					return err
+				}
+				repoID = id
+			}
+
+			_, err := hgsrht.UpdateACL(c.Client, ctx, repoID, hgsrht.AccessMode(permissions[0]), entity)
+			return err
+		},
+		Delete: func(ctx context.Context, id int32) error {
+			_, err := hgsrht.DeleteACL(c.Client, ctx, id)
+			return err
+		},
+	}
+}
+
 func newHgUserWebhookCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "user-webhook",
@@ -483,16 +632,16 @@ func newHgUserWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newHgUserWebhookCreateCommand())
 	cmd.AddCommand(newHgUserWebhookListCommand())
 	cmd.AddCommand(newHgUserWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadHgUserWebhookDeliveries, loadHgUserWebhookSample, nil)
 	return cmd
 }
 
 func newHgUserWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		c := createClient("hg", cmd)
 
 		var config hgsrht.UserWebhookInput
 		config.Url = url
@@ -503,6 +652,19 @@ func newHgUserWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("hg", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("hg", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClient("hg", cmd)
 
 		webhook, err := hgsrht.CreateUserWebhook(c.Client, ctx, config)
 		if err != nil {
@@ -528,7 +690,10 @@ func newHgUserWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -601,6 +766,50 @@ func newHgUserWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadHgUserWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	c := createClient("hg", cmd)
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		webhook, err := hgsrht.UserWebhookDeliveries(c.Client, ctx, id, (*hgsrht.Cursor)(cursor))
+		if err != nil {
+			return nil, nil, err
+		} else if webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertHgWebhookDeliveries(webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadHgUserWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	c := createClient("hg", cmd)
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		webhook, err := hgsrht.UserWebhookSample(c.Client, ctx, id, hgsrht.WebhookEvent(event))
+		if err != nil {
+			return "", err
+		} else if webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return webhook.Sample, nil
+	}
+}
+
+func convertHgWebhookDeliveries(cursor *hgsrht.WebhookDeliveryCursor) ([]webhookDelivery, *string) {
+	var deliveries []webhookDelivery
+	for _, delivery := range cursor.Results {
+		deliveries = append(deliveries, webhookDelivery{
+			UUID:            delivery.Uuid,
+			Date:            delivery.Date.Time,
+			Event:           string(delivery.Event),
+			RequestBody:     delivery.RequestBody,
+			ResponseBody:    delivery.ResponseBody,
+			ResponseHeaders: delivery.ResponseHeaders,
+			ResponseStatus:  delivery.ResponseStatus,
+		})
+	}
+	return deliveries, (*string)(cursor.Cursor)
+}
+
 func getHgRepoName(ctx context.Context, cmd *cobra.Command) (repoName, owner, instance string, err error) {
 	repoName, err = cmd.Flags().GetString("repo")
 	if err != nil {
@@ -678,6 +887,32 @@ func getHgRepoID(c *Client, ctx context.Context, name, owner string) (int32, err
 	return user.Repository.Id, nil
 }
 
+// getHgReposMatching returns the repositories of the current user whose name
+// matches a glob pattern.
+func getHgReposMatching(c *Client, ctx context.Context, pattern string) ([]bulkRepo, error) {
+	var (
+		repos  []bulkRepo
+		cursor *hgsrht.Cursor
+	)
+	for {
+		repositories, err := hgsrht.Repositories(c.Client, ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repo := range repositories.Results {
+			if matchRepoName(pattern, repo.Name) {
+				repos = append(repos, bulkRepo{ID: repo.Id, Name: repo.Name})
+			}
+		}
+
+		cursor = repositories.Cursor
+		if cursor == nil {
+			return repos, nil
+		}
+	}
+}
+
 func completeHgUserWebhookEvents(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	var eventList []string
 	events := [3]string{"repo_created", "repo_update", "repo_deleted"}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"git.sr.ht/~xenrox/hut/termfmt"
+)
+
+// mboxPatch is a patch extracted from an mbox.
+type mboxPatch struct {
+	Subject string // without the "[PATCH ...]" prefix
+	Author  string // email address
+	Body    string
+}
+
+var patchSubjectPrefixRegexp = regexp.MustCompile(`^(\[[^\]]*\]\s*)+`)
+
+// splitMbox splits an mbox into patches. Cover letters are skipped.
+func splitMbox(mbox string) []mboxPatch {
+	var patches []mboxPatch
+	for _, msg := range readMboxMessages(mbox) {
+		m, err := mail.ReadMessage(strings.NewReader(msg))
+		if err != nil {
+			continue
+		}
+		b, err := io.ReadAll(m.Body)
+		if err != nil {
+			continue
+		}
+		if decoded, err := decodeTransferEncoding(m.Header.Get("Content-Transfer-Encoding"), b); err == nil {
+			b = decoded
+		}
+		body := string(b)
+
+		if !strings.Contains(body, "\n---") && !strings.HasPrefix(body, "---") {
+			continue
+		}
+
+		subject := m.Header.Get("Subject")
+		if decoded, err := new(mime.WordDecoder).DecodeHeader(subject); err == nil {
+			subject = decoded
+		}
+		var author string
+		if addr, err := mail.ParseAddress(m.Header.Get("From")); err == nil {
+			author = addr.Address
+		}
+
+		patches = append(patches, mboxPatch{
+			Subject: patchSubjectPrefixRegexp.ReplaceAllString(subject, ""),
+			Author:  author,
+			Body:    body,
+		})
+	}
+	return patches
+}
+
+// writeInterdiff writes the differences between two series of patches. Patches
+// are matched by subject.
+func writeInterdiff(w io.Writer, old, new []mboxPatch) {
+	used := make([]bool, len(old))
+	for j, patch := range new {
+		i := -1
+		for k := range old {
+			if !used[k] && old[k].Subject == patch.Subject {
+				i = k
+				break
+			}
+		}
+
+		if i < 0 {
+			fmt.Fprintln(w, termfmt.Green.Sprintf("-:  ------- > %d:  %s", j+1, patch.Subject))
+			continue
+		}
+		used[i] = true
+
+		if old[i].Body == patch.Body {
+			fmt.Fprintf(w, "%d:  = %d:  %s\n", i+1, j+1, patch.Subject)
+			continue
+		}
+		fmt.Fprintln(w, termfmt.Yellow.Sprintf("%d:  ! %d:  %s", i+1, j+1, patch.Subject))
+		writeUnifiedDiff(w, splitLines(old[i].Body), splitLines(patch.Body))
+	}
+
+	for i, patch := range old {
+		if !used[i] {
+			fmt.Fprintln(w, termfmt.Red.Sprintf("%d:  < -:  ------- %s", i+1, patch.Subject))
+		}
+	}
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+type diffOp struct {
+	Kind byte // ' ', '-' or '+'
+	Line string
+}
+
+// diffLines computes the shortest edit script between a and b with the linear
+// space variant of Myers' algorithm.
+func diffLines(a, b []string) []diffOp {
+	maxD := (len(a) + len(b) + 1) / 2
+	vf := make([]int, 2*maxD+3)
+	vb := make([]int, 2*maxD+3)
+	return appendDiff(nil, a, b, vf, vb)
+}
+
+// appendDiff appends the edit script between a and b to ops. vf and vb are
+// scratch space for middleSnake, shared by all recursive calls.
+func appendDiff(ops []diffOp, a, b []string, vf, vb []int) []diffOp {
+	var prefix, suffix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	common := a[len(a)-suffix:]
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			ops = append(ops, diffOp{'+', line})
+		}
+	case len(b) == 0:
+		for _, line := range a {
+			ops = append(ops, diffOp{'-', line})
+		}
+	default:
+		// At least two edits are left, each half has fewer
+		x, y, u, v := middleSnake(a, b, vf, vb)
+		ops = appendDiff(ops, a[:x], b[:y], vf, vb)
+		for _, line := range a[x:u] {
+			ops = append(ops, diffOp{' ', line})
+		}
+		ops = appendDiff(ops, a[u:], b[v:], vf, vb)
+	}
+
+	for _, line := range common {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+// middleSnake finds the middle snake of an optimal path from (0, 0) to
+// (len(a), len(b)), by searching forward from the start and backward from
+// the end until the paths overlap. The snake goes from (x, y) to (u, v).
+func middleSnake(a, b []string, vf, vb []int) (x, y, u, v int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+	offset := len(vf) / 2
+	vf[offset+1] = 0
+	vb[offset+1] = 0
+
+	for d := 0; d <= (n+m+1)/2; d++ {
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1]
+			} else {
+				x = vf[offset+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < m && a[u] == b[v] {
+				u++
+				v++
+			}
+			vf[offset+k] = u
+			if odd && k >= delta-(d-1) && k <= delta+(d-1) && u+vb[offset+delta-k] >= n {
+				return x, y, u, v
+			}
+		}
+
+		// Backward, with coordinates measured from the end
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < m && a[n-1-u] == b[m-1-v] {
+				u++
+				v++
+			}
+			vb[offset+k] = u
+			if !odd && delta-k >= -d && delta-k <= d && u+vf[offset+delta-k] >= n {
+				return n - u, m - v, n - x, m - y
+			}
+		}
+	}
+	panic("unreachable")
+}
+
+// writeUnifiedDiff writes the differences between a and b in the unified
+// format, with three lines of context.
+func writeUnifiedDiff(w io.Writer, a, b []string) {
+	const context = 3
+
+	ops := diffLines(a, b)
+	for start := 0; start < len(ops); {
+		// Find the next change
+		for start < len(ops) && ops[start].Kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk until there are more than 2*context unchanged lines
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].Kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*context {
+				break
+			}
+		}
+
+		from := max(start-context, 0)
+		to := min(end+context, len(ops))
+
+		var oldStart, newStart, oldLines, newLines int
+		for _, op := range ops[:from] {
+			if op.Kind != '+' {
+				oldStart++
+			}
+			if op.Kind != '-' {
+				newStart++
+			}
+		}
+		for _, op := range ops[from:to] {
+			if op.Kind != '+' {
+				oldLines++
+			}
+			if op.Kind != '-' {
+				newLines++
+			}
+		}
+
+		// Empty ranges start at the line before them
+		if oldLines > 0 {
+			oldStart++
+		}
+		if newLines > 0 {
+			newStart++
+		}
+		fmt.Fprintln(w, termfmt.Blue.Sprintf("    @@ -%d,%d +%d,%d @@", oldStart, oldLines, newStart, newLines))
+		for _, op := range ops[from:to] {
+			line := fmt.Sprintf("    %c%s", op.Kind, op.Line)
+			switch op.Kind {
+			case '-':
+				line = termfmt.Red.String(line)
+			case '+':
+				line = termfmt.Green.String(line)
+			}
+			fmt.Fprintln(w, line)
+		}
+
+		start = to
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitMbox(t *testing.T) {
+	mbox := `From 0000 Mon Sep 17 00:00:00 2001
+From: Alice <alice@example.org>
+Subject: [PATCH v2 0/2] Add foo
+
+Cover letter
+
+From 1111 Mon Sep 17 00:00:00 2001
+From: Alice <alice@example.org>
+Subject: [PATCH v2 1/2] Add foo
+
+Body
+>From the start
+---
+ foo | 1 +
+
+From 2222 Mon Sep 17 00:00:00 2001
+From: =?utf-8?q?Bj=C3=B6rn?= <bjorn@example.org>
+Subject: [PATCH v2 2/2] =?utf-8?q?Fix_b=C3=A4r?=
+
+---
+ bar | 1 -
+
+From 3333 Mon Sep 17 00:00:00 2001
+From: Alice <alice@example.org>
+Subject: [PATCH] Add qux
+Content-Transfer-Encoding: quoted-printable
+
+Caf=C3=A9 =
+au lait
+---
+ qux | 1 +
+
+From 4444 Mon Sep 17 00:00:00 2001
+From: Alice <alice@example.org>
+Subject: [PATCH] Add baz
+Content-Transfer-Encoding: base64
+
+Q2Fmw6kKLS0tCiBiYXogfCAxICsK
+`
+
+	want := []mboxPatch{
+		{"Add foo", "alice@example.org", "Body\nFrom the start\n---\n foo | 1 +\n"},
+		{"Fix bär", "bjorn@example.org", "---\n bar | 1 -\n"},
+		{"Add qux", "alice@example.org", "Café au lait\n---\n qux | 1 +\n"},
+		{"Add baz", "alice@example.org", "Café\n---\n baz | 1 +\n"},
+	}
+	got := splitMbox(mbox)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitMbox: expected %q, got %q", want, got)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"", "", ""},
+		{"a b c", "a b c", " a b c"},
+		{"", "a b", "+a+b"},
+		{"a b", "", "-a-b"},
+		{"a b c", "a x c", " a-b+x c"},
+		{"a b c d", "b c d e", "-a b c d+e"},
+		{"x a b c y", "a b c", "-x a b c-y"},
+	}
+
+	for _, test := range tests {
+		var got strings.Builder
+		for _, op := range diffLines(strings.Fields(test.a), strings.Fields(test.b)) {
+			got.WriteByte(op.Kind)
+			got.WriteString(op.Line)
+		}
+		if got.String() != test.want {
+			t.Errorf("diffLines(%q, %q): expected %q, got %q", test.a, test.b, test.want, got.String())
+		}
+	}
+}
+
+func TestWriteUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"a b c", "a b c", ""},
+		{"", "x", "    @@ -0,0 +1,1 @@\n    +x\n"},
+		{
+			"1 2 3 4 5 6 7 8 9 10",
+			"1 2 3 4 five 6 7 8 9 10",
+			"    @@ -2,7 +2,7 @@\n     2\n     3\n     4\n    -5\n    +five\n     6\n     7\n     8\n",
+		},
+		{
+			"1 2 3 4 5 6 7 8 9 10 11 12",
+			"one 2 3 4 5 6 7 8 9 10 11 twelve",
+			"    @@ -1,4 +1,4 @@\n    -1\n    +one\n     2\n     3\n     4\n" +
+				"    @@ -9,4 +9,4 @@\n     9\n     10\n     11\n    -12\n    +twelve\n",
+		},
+	}
+
+	for _, test := range tests {
+		var got strings.Builder
+		writeUnifiedDiff(&got, strings.Fields(test.a), strings.Fields(test.b))
+		if got.String() != test.want {
+			t.Errorf("writeUnifiedDiff(%q, %q): expected %q, got %q", test.a, test.b, test.want, got.String())
+		}
+	}
+}
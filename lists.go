@@ -3,17 +3,27 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"mime"
 	"net/http"
 	"net/mail"
+	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/dustin/go-humanize"
+	"github.com/juju/ansiterm/tabwriter"
 
 	"github.com/spf13/cobra"
 
@@ -33,7 +43,10 @@ func newListsCommand() *cobra.Command {
 	cmd.AddCommand(newListsUnsubscribeCommand())
 	cmd.AddCommand(newListsCreateCommand())
 	cmd.AddCommand(newListsArchiveCommand())
+	cmd.AddCommand(newListsSyncCommand())
 	cmd.AddCommand(newListsPatchsetCommand())
+	cmd.AddCommand(newListsThreadCommand())
+	cmd.AddCommand(newListsReplyCommand())
 	cmd.AddCommand(newListsACLCommand())
 	cmd.AddCommand(newListsUserWebhookCommand())
 	cmd.AddCommand(newListsWebhookCommand())
@@ -467,6 +480,271 @@ func newListsArchiveCommand() *cobra.Command {
 	return cmd
 }
 
+func newListsSyncCommand() *cobra.Command {
+	var days int
+	var stateFilename string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		var name, owner, instance string
+		if len(args) > 1 {
+			name, owner, instance = parseMailingListName(args[0])
+		} else {
+			var err error
+			name, owner, instance, err = getMailingListName(ctx, cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		target := args[len(args)-1]
+
+		if stateFilename == "" {
+			var err error
+			stateFilename, err = getListsSyncStateFilename(target)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		state, err := loadListsSyncState(stateFilename)
+		if err != nil {
+			log.Fatalf("failed to load sync state: %v", err)
+		}
+
+		c := createClientWithInstance("lists", cmd, instance)
+
+		mailbox, err := openLocalMailbox(target)
+		if err != nil {
+			log.Fatalf("failed to open mailbox: %v", err)
+		}
+		defer mailbox.Close()
+
+		var n int
+		deliver := func(msg []byte, id string) error {
+			if err := mailbox.Deliver(msg); err != nil {
+				return err
+			}
+			state.add(id)
+			n++
+			return nil
+		}
+
+		err = syncListsEmails(ctx, c, name, owner, state, days, deliver)
+		if saveErr := state.save(stateFilename); saveErr != nil {
+			log.Printf("Failed to save sync state: %v", saveErr)
+		}
+		if err != nil {
+			mailbox.Close()
+			log.Fatal(err)
+		}
+
+		if n == 0 {
+			log.Println("Already up to date")
+		} else {
+			log.Printf("Synchronized %d new emails\n", n)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sync [list] <maildir|mbox>",
+		Short: "Synchronize a mailing list to a Maildir or mbox",
+		Args:  cobra.RangeArgs(1, 2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				lists, _ := completeList(cmd, args, toComplete)
+				return lists, cobra.ShellCompDirectiveDefault
+			}
+			return nil, cobra.ShellCompDirectiveDefault
+		},
+		Run: run,
+	}
+	cmd.Flags().IntVarP(&days, "days", "d", 0, "number of last days to download on the first sync")
+	cmd.RegisterFlagCompletionFunc("days", cobra.NoFileCompletions)
+	cmd.Flags().StringVar(&stateFilename, "state", "", "sync state file")
+	return cmd
+}
+
+// listsSyncState records the emails already synchronized to a mailbox.
+type listsSyncState struct {
+	List         string    `json:"list"`
+	LastReceived time.Time `json:"last_received"`
+	MessageIDs   []string  `json:"message_ids"`
+
+	seen map[string]bool
+}
+
+func (state *listsSyncState) has(messageID string) bool {
+	return state.seen[messageID]
+}
+
+func (state *listsSyncState) add(messageID string) {
+	if messageID == "" || state.seen[messageID] {
+		return
+	}
+	state.seen[messageID] = true
+	state.MessageIDs = append(state.MessageIDs, messageID)
+}
+
+func (state *listsSyncState) save(filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	// Replace the file atomically, to not lose the state on failure
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+func loadListsSyncState(filename string) (*listsSyncState, error) {
+	state := &listsSyncState{seen: make(map[string]bool)}
+
+	b, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, err
+	}
+	for _, id := range state.MessageIDs {
+		state.seen[id] = true
+	}
+	return state, nil
+}
+
+// getListsSyncStateFilename returns the default state file for a mailbox,
+// stored in the cache directory.
+func getListsSyncStateFilename(target string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find cache directory: %v", err)
+	}
+
+	target, err = filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(target))
+	return filepath.Join(dir, "hut", "lists-sync", hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// syncListsEmails delivers the emails of a mailing list missing from state.
+// On the first sync, the archive is streamed and split into emails as it is
+// downloaded. Later on, emails received since the last sync are fetched one by
+// one.
+func syncListsEmails(ctx context.Context, c *Client, name, owner string, state *listsSyncState, days int, deliver func(msg []byte, id string) error) error {
+	// Emails may show up with a delay, don't rely on exact timestamps
+	const receivedSlack = time.Hour
+
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	var (
+		cursor    *listssrht.Cursor
+		newest    time.Time
+		archive   string
+		envelopes []string
+	)
+	for {
+		var user *listssrht.User
+		var err error
+		if username != "" {
+			user, err = listssrht.SyncEmailsByUser(c.Client, ctx, username, name, cursor)
+		} else {
+			user, err = listssrht.SyncEmails(c.Client, ctx, name, cursor)
+		}
+		if err != nil {
+			return err
+		} else if user == nil {
+			return fmt.Errorf("no such user %q", username)
+		} else if user.List == nil {
+			return fmt.Errorf("no such mailing list %q", name)
+		}
+
+		list := user.List
+		listName := formatResourceName(list.Name, list.Owner.CanonicalName)
+		if state.List == "" {
+			state.List = listName
+		} else if state.List != listName {
+			return fmt.Errorf("mailbox is synchronized with another mailing list (%v)", state.List)
+		}
+		archive = string(list.Archive)
+
+		var done bool
+		for _, email := range list.Emails.Results {
+			if newest.IsZero() {
+				newest = email.Received.Time
+			}
+			if state.LastReceived.IsZero() || email.Received.Time.Before(state.LastReceived.Add(-receivedSlack)) {
+				done = true
+				break
+			}
+			if !state.has(email.MessageID) {
+				envelopes = append(envelopes, string(email.Envelope))
+			}
+		}
+
+		cursor = list.Emails.Cursor
+		if done || cursor == nil {
+			break
+		}
+	}
+
+	if state.LastReceived.IsZero() {
+		url := archive
+		if days != 0 {
+			url = fmt.Sprintf("%s?since=%d", url, days)
+		}
+
+		timeout := c.HTTP.Timeout
+		c.HTTP.Timeout = fileTransferTimeout
+		body, err := openListsFile(ctx, c, url)
+		c.HTTP.Timeout = timeout
+		if err != nil {
+			return fmt.Errorf("failed to fetch archive: %v", err)
+		}
+		defer body.Close()
+
+		err = scanMboxMessages(body, func(msg string) error {
+			id := messageID([]byte(msg))
+			if id != "" && state.has(id) {
+				return nil
+			}
+			return deliver([]byte(msg), id)
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		// Emails are listed in reverse chronological order
+		for i := len(envelopes) - 1; i >= 0; i-- {
+			var buf bytes.Buffer
+			if err := fetchListsFile(ctx, c, envelopes[i], &buf); err != nil {
+				return fmt.Errorf("failed to fetch email: %v", err)
+			}
+			if err := deliver(buf.Bytes(), messageID(buf.Bytes())); err != nil {
+				return err
+			}
+		}
+	}
+
+	if newest.After(state.LastReceived) {
+		state.LastReceived = newest
+	}
+	return nil
+}
+
 func newListsPatchsetCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "patchset",
@@ -476,6 +754,11 @@ func newListsPatchsetCommand() *cobra.Command {
 	cmd.AddCommand(newListsPatchsetUpdateCommand())
 	cmd.AddCommand(newListsPatchsetApplyCommand())
 	cmd.AddCommand(newListsPatchsetShowCommand())
+	cmd.AddCommand(newListsPatchsetToolCommand())
+	cmd.AddCommand(newListsPatchsetCICommand())
+	cmd.AddCommand(newListsPatchsetSendCommand())
+	cmd.AddCommand(newListsPatchsetDiffCommand())
+	cmd.AddCommand(newListsPatchsetReconcileCommand())
 	return cmd
 }
 
@@ -606,6 +889,13 @@ func printPatchset(w io.Writer, patchset *listssrht.Patchset, byUser bool, filte
 	if patchset.Version != 1 {
 		s += fmt.Sprintf(" v%d", patchset.Version)
 	}
+	if len(patchset.Tools) > 0 {
+		var icons []string
+		for _, tool := range patchset.Tools {
+			icons = append(icons, tool.Icon.TermIcon())
+		}
+		s += " " + strings.Join(icons, "")
+	}
 
 	created := termfmt.Dim.String(humanize.Time(patchset.Created.Time))
 
@@ -658,6 +948,7 @@ func newListsPatchsetUpdateCommand() *cobra.Command {
 }
 
 func newListsPatchsetShowCommand() *cobra.Command {
+	var coverLetter bool
 	cmd := cobra.Command{
 		Use:               "show <ID>",
 		Short:             "Show a patchset",
@@ -672,30 +963,34 @@ func newListsPatchsetShowCommand() *cobra.Command {
 			log.Fatal(err)
 		}
 		c := createClientWithInstance("lists", cmd, instance)
-		var cursor *listssrht.Cursor
 
-		for {
-			patchset, err := listssrht.PatchsetById(c.Client, ctx, id, cursor)
-			if err != nil {
-				log.Fatal(err)
-			} else if patchset == nil {
-				log.Fatalf("no such patchset %d", id)
-			}
+		patchset, err := getPatchsetMbox(ctx, c, id)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-			for _, patch := range patchset.Patches.Results {
-				formatPatch(os.Stdout, &patch)
-			}
+		// Keep stdout a valid mbox
+		for _, tool := range patchset.Tools {
+			log.Printf("%s %s\n", tool.Icon.TermIcon(), tool.Details)
+		}
 
-			cursor = patchset.Patches.Cursor
-			if cursor == nil {
-				break
+		if coverLetter && patchset.CoverLetter != nil {
+			if err := writeCoverLetter(ctx, c, os.Stdout, patchset.CoverLetter); err != nil {
+				log.Fatal(err)
 			}
 		}
+
+		if err := fetchListsFile(ctx, c, string(patchset.Mbox), os.Stdout); err != nil {
+			log.Fatalf("failed to fetch patchset: %v", err)
+		}
 	}
+	cmd.Flags().BoolVar(&coverLetter, "cover-letter", false, "include the cover letter")
 	return &cmd
 }
 
 func newListsPatchsetApplyCommand() *cobra.Command {
+	var coverLetter, addLink, signoff bool
+	var branch string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 
@@ -704,53 +999,1541 @@ func newListsPatchsetApplyCommand() *cobra.Command {
 			log.Fatal(err)
 		}
 		c := createClientWithInstance("lists", cmd, instance)
-		var cursor *listssrht.Cursor
+
+		patchset, err := getPatchsetMbox(ctx, c, id)
+		if err != nil {
+			log.Fatal(err)
+		}
+
 		var mbox bytes.Buffer
+		if err := fetchListsFile(ctx, c, string(patchset.Mbox), &mbox); err != nil {
+			log.Fatalf("failed to fetch patchset: %v", err)
+		}
 
-		for {
-			patchset, err := listssrht.PatchsetById(c.Client, ctx, id, cursor)
+		if coverLetter && patchset.CoverLetter != nil {
+			fmt.Println(termfmt.Bold.String(patchset.CoverLetter.Subject))
+			fmt.Println()
+			fmt.Println(strings.TrimSpace(strings.ReplaceAll(patchset.CoverLetter.Body, "\r\n", "\n")))
+			fmt.Println()
+		}
+
+		if branch != "" {
+			if err := runGitCommand("checkout", "-b", branch); err != nil {
+				log.Fatalf("failed to create branch %q: %v", branch, err)
+			}
+
+			if coverLetter && patchset.CoverLetter != nil {
+				description := patchset.CoverLetter.Subject + "\n\n" + patchset.CoverLetter.Body
+				if err := runGitCommand("config", "branch."+branch+".description", description); err != nil {
+					log.Fatalf("failed to set branch description: %v", err)
+				}
+			}
+		}
+
+		amArgs := []string{"am", "-3"}
+		if signoff {
+			amArgs = append(amArgs, "--signoff")
+		}
+		if addLink {
+			link := fmt.Sprintf("%s/%s/%s/patches/%d", c.BaseURL,
+				patchset.List.Owner.CanonicalName, patchset.List.Name, patchset.Id)
+			b, err := addMboxTrailer(mbox.String(), "Link: "+link)
+			if err != nil {
+				log.Fatalf("failed to add link trailers: %v", err)
+			}
+			mbox.Reset()
+			mbox.Write(b)
+			amArgs = append(amArgs, "--patch-format=mboxrd")
+		}
+		applyCmd := exec.Command("git", amArgs...)
+		applyCmd.Stdin = &mbox
+		applyCmd.Stdout = os.Stdout
+		applyCmd.Stderr = os.Stderr
+
+		if err := applyCmd.Run(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "apply <ID>",
+		Short:             "Apply a patchset",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePatchsetID,
+		Run:               run,
+	}
+	cmd.Flags().BoolVar(&coverLetter, "cover-letter", false, "print the cover letter")
+	cmd.Flags().BoolVar(&addLink, "add-link", false, "add a Link trailer to the archive")
+	cmd.Flags().BoolVarP(&signoff, "signoff", "s", false, "add a Signed-off-by trailer")
+	cmd.Flags().StringVarP(&branch, "branch", "b", "", "create a review branch")
+	cmd.RegisterFlagCompletionFunc("branch", cobra.NoFileCompletions)
+	return cmd
+}
+
+func newListsPatchsetDiffCommand() *cobra.Command {
+	var previous bool
+	var base string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		if previous && len(args) != 1 {
+			log.Fatal("--previous requires exactly one patchset ID")
+		} else if !previous && len(args) != 2 {
+			log.Fatal("expected two patchset IDs, or one with --previous")
+		}
+
+		newID, instance, err := parsePatchID(ctx, cmd, args[len(args)-1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		c := createClientWithInstance("lists", cmd, instance)
+
+		newPatchset, err := listssrht.PatchsetInfo(c.Client, ctx, newID)
+		if err != nil {
+			log.Fatal(err)
+		} else if newPatchset == nil {
+			log.Fatalf("no such patchset %d", newID)
+		}
+
+		var oldPatchset *listssrht.Patchset
+		if previous {
+			oldPatchset, err = findSupersededPatchset(ctx, c, newPatchset)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("Comparing with v%d (#%d)\n", oldPatchset.Version, oldPatchset.Id)
+		} else {
+			oldID, _, err := parsePatchID(ctx, cmd, args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			oldPatchset, err = listssrht.PatchsetInfo(c.Client, ctx, oldID)
 			if err != nil {
 				log.Fatal(err)
-			} else if patchset == nil {
-				log.Fatalf("no such patchset %d", id)
+			} else if oldPatchset == nil {
+				log.Fatalf("no such patchset %d", oldID)
+			}
+		}
+
+		var oldMbox, newMbox bytes.Buffer
+		if err := fetchListsFile(ctx, c, string(oldPatchset.Mbox), &oldMbox); err != nil {
+			log.Fatalf("failed to fetch patchset: %v", err)
+		}
+		if err := fetchListsFile(ctx, c, string(newPatchset.Mbox), &newMbox); err != nil {
+			log.Fatalf("failed to fetch patchset: %v", err)
+		}
+
+		if _, err := exec.LookPath("git"); err == nil {
+			err := runRangeDiff(base, oldMbox.Bytes(), newMbox.Bytes())
+			if err == nil {
+				return
+			}
+			log.Printf("%v, falling back to interdiff", err)
+		}
+
+		oldPatches := splitMbox(oldMbox.String())
+		newPatches := splitMbox(newMbox.String())
+		err = pagerify(func(p pager) error {
+			writeInterdiff(p, oldPatches, newPatches)
+			return pagerDone
+		}, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "diff [old-ID] <new-ID>",
+		Short:             "Compare two versions of a patchset",
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completePatchsetID,
+		Run:               run,
+	}
+	cmd.Flags().BoolVarP(&previous, "previous", "p", false, "compare with the previous version")
+	cmd.Flags().StringVar(&base, "base", "HEAD", "revision to apply patchsets on")
+	cmd.RegisterFlagCompletionFunc("base", cobra.NoFileCompletions)
+	return cmd
+}
+
+// findSupersededPatchset looks for the previous version of a patchset: either
+// the patchset superseded by it, or the latest older version with the same
+// subject and submitter.
+func findSupersededPatchset(ctx context.Context, c *Client, patchset *listssrht.Patchset) (*listssrht.Patchset, error) {
+	// Only look at the most recent patchsets
+	const maxPages = 5
+
+	username := strings.TrimLeft(patchset.List.Owner.CanonicalName, ownerPrefixes)
+	var cursor *listssrht.Cursor
+	var candidate *listssrht.Patchset
+	for i := 0; i < maxPages; i++ {
+		user, err := listssrht.ListPatchesByUser(c.Client, ctx, username, patchset.List.Name, cursor)
+		if err != nil {
+			return nil, err
+		} else if user == nil || user.List == nil {
+			return nil, fmt.Errorf("no such list %q", patchset.List.Name)
+		}
+
+		for _, p := range user.List.Patches.Results {
+			if p.SupersededBy != nil && p.SupersededBy.Id == patchset.Id {
+				return infoPatchset(ctx, c, p.Id)
+			}
+			if candidate == nil && p.Id != patchset.Id && p.Version < patchset.Version &&
+				p.Submitter.CanonicalName == patchset.Submitter.CanonicalName &&
+				strings.EqualFold(p.Subject, patchset.Subject) {
+				candidate = &p
 			}
+		}
+
+		cursor = user.List.Patches.Cursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	if candidate == nil {
+		return nil, fmt.Errorf("no previous version found for patchset %d", patchset.Id)
+	}
+	return infoPatchset(ctx, c, candidate.Id)
+}
+
+func infoPatchset(ctx context.Context, c *Client, id int32) (*listssrht.Patchset, error) {
+	patchset, err := listssrht.PatchsetInfo(c.Client, ctx, id)
+	if err != nil {
+		return nil, err
+	} else if patchset == nil {
+		return nil, fmt.Errorf("no such patchset %d", id)
+	}
+	return patchset, nil
+}
+
+// runRangeDiff applies both patchsets on base and runs git range-diff.
+func runRangeDiff(base string, oldMbox, newMbox []byte) error {
+	b, err := exec.Command("git", "rev-parse", "--verify", base+"^{commit}").Output()
+	if err != nil {
+		return fmt.Errorf("failed to resolve %q", base)
+	}
+	base = strings.TrimSpace(string(b))
+
+	var heads [2]string
+	for i, mbox := range [][]byte{oldMbox, newMbox} {
+		err := withPatchedWorktree(base, mbox, func(dir string) error {
+			b, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+			heads[i] = strings.TrimSpace(string(b))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	rangeDiffCmd := exec.Command("git", "range-diff", base+".."+heads[0], base+".."+heads[1])
+	rangeDiffCmd.Stdin = os.Stdin
+	rangeDiffCmd.Stdout = os.Stdout
+	rangeDiffCmd.Stderr = os.Stderr
+	if err := rangeDiffCmd.Run(); err != nil {
+		return fmt.Errorf("git range-diff failed: %v", err)
+	}
+	return nil
+}
+
+func newListsPatchsetReconcileCommand() *cobra.Command {
+	var count int
+	var branch string
+	var dryRun, autoConfirm bool
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		var name, owner, instance string
+		if len(args) > 0 {
+			name, owner, instance = parseMailingListName(args[0])
+		} else {
+			var err error
+			name, owner, instance, err = getMailingListName(ctx, cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		c := createClientWithInstance("lists", cmd, instance)
+
+		var username string
+		if owner != "" {
+			username = strings.TrimLeft(owner, ownerPrefixes)
+		}
+
+		var patchsets []listssrht.Patchset
+		var cursor *listssrht.Cursor
+		for len(patchsets) < count {
+			var user *listssrht.User
+			var err error
+			if username != "" {
+				user, err = listssrht.ListPatchesByUser(c.Client, ctx, username, name, cursor)
+			} else {
+				user, err = listssrht.ListPatches(c.Client, ctx, name, cursor)
+			}
+			if err != nil {
+				log.Fatal(err)
+			} else if user == nil {
+				log.Fatalf("no such user %q", username)
+			} else if user.List == nil {
+				log.Fatalf("no such list %q", name)
+			}
+
+			patchsets = append(patchsets, user.List.Patches.Results...)
+			cursor = user.List.Patches.Cursor
+			if cursor == nil {
+				break
+			}
+		}
+		if len(patchsets) > count {
+			patchsets = patchsets[:count]
+		}
+
+		var updates []patchsetStatusUpdate
+		var candidates []*listssrht.Patchset
+		for i := range patchsets {
+			patchset := &patchsets[i]
+			if !patchsetApplicable(patchset.Status) {
+				continue
+			}
+			if patchset.SupersededBy != nil || hasNewerPatchsetVersion(patchsets, patchset) {
+				updates = append(updates, patchsetStatusUpdate{patchset, listssrht.PatchsetStatusSuperseded})
+				continue
+			}
+			candidates = append(candidates, patchset)
+		}
+
+		if len(candidates) > 0 {
+			// Applied patches can't be committed before they were sent
+			since := candidates[0].Created.Time
+			for _, patchset := range candidates {
+				if patchset.Created.Time.Before(since) {
+					since = patchset.Created.Time
+				}
+			}
+			commits, err := loadGitCommits(branch, since.Add(-24*time.Hour))
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			for _, patchset := range candidates {
+				applied, likely, total, err := countAppliedPatches(ctx, c, patchset.Id, commits)
+				if err != nil {
+					log.Fatalf("failed to check patchset %d: %v", patchset.Id, err)
+				}
+				if total > 0 && applied == total {
+					updates = append(updates, patchsetStatusUpdate{patchset, listssrht.PatchsetStatusApplied})
+				} else if total > 0 && applied+likely == total {
+					log.Printf("Patchset #%d is likely applied (%d/%d patches only match by subject and author), skipping\n", patchset.Id, likely, total)
+				} else if applied+likely > 0 {
+					log.Printf("Patchset #%d is partially applied (%d/%d patches), skipping\n", patchset.Id, applied+likely, total)
+				}
+			}
+		}
+
+		if len(updates) == 0 {
+			log.Println("No patchset to update")
+			return
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, update := range updates {
+			subject := update.Patchset.Subject
+			if update.Patchset.Version != 1 {
+				subject += fmt.Sprintf(" v%d", update.Patchset.Version)
+			}
+			fmt.Fprintf(tw, "%s\t%s → %s\t%s\t%s\n", termfmt.DarkYellow.Sprintf("#%d", update.Patchset.Id),
+				update.Patchset.Status.TermString(), update.Status.TermString(), subject,
+				update.Patchset.Submitter.CanonicalName)
+		}
+		tw.Flush()
+
+		if dryRun {
+			return
+		}
+		if !autoConfirm && !getConfirmation(fmt.Sprintf("Update %d patchsets", len(updates))) {
+			log.Println("Aborted")
+			return
+		}
+
+		var failed int
+		for _, update := range updates {
+			if _, err := listssrht.UpdatePatchset(c.Client, ctx, update.Patchset.Id, update.Status); err != nil {
+				log.Printf("Failed to update patchset #%d: %v\n", update.Patchset.Id, err)
+				failed++
+			}
+		}
+		log.Printf("Updated %d patchsets\n", len(updates)-failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "reconcile [list]",
+		Short:             "Update the status of applied and superseded patchsets",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeList,
+		Run:               run,
+	}
+	cmd.Flags().StringVarP(&branch, "branch", "b", "HEAD", "branch to look for applied patches in")
+	cmd.RegisterFlagCompletionFunc("branch", cobra.NoFileCompletions)
+	cmd.Flags().IntVar(&count, "count", 100, "number of recent patchsets to check")
+	cmd.RegisterFlagCompletionFunc("count", cobra.NoFileCompletions)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the changes")
+	cmd.Flags().BoolVarP(&autoConfirm, "yes", "y", false, "auto confirm")
+	return cmd
+}
+
+type patchsetStatusUpdate struct {
+	Patchset *listssrht.Patchset
+	Status   listssrht.PatchsetStatus
+}
+
+func hasNewerPatchsetVersion(patchsets []listssrht.Patchset, patchset *listssrht.Patchset) bool {
+	for _, other := range patchsets {
+		if other.Version > patchset.Version &&
+			other.Submitter.CanonicalName == patchset.Submitter.CanonicalName &&
+			strings.EqualFold(other.Subject, patchset.Subject) {
+			return true
+		}
+	}
+	return false
+}
+
+// countAppliedPatches returns how many patches of a patchset are found in
+// commits by patch ID, and how many are only likely applied.
+func countAppliedPatches(ctx context.Context, c *Client, id int32, commits []gitCommit) (applied, likely, total int, err error) {
+	patchset, err := infoPatchset(ctx, c, id)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	var buf bytes.Buffer
+	if err := fetchListsFile(ctx, c, string(patchset.Mbox), &buf); err != nil {
+		return 0, 0, 0, err
+	}
+
+	patches := splitMbox(buf.String())
+	for _, patch := range patches {
+		patchIDs, err := gitPatchIDs([]byte(patch.Body))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		var patchID string
+		if len(patchIDs) > 0 {
+			patchID = patchIDs[0][0]
+		}
+		switch matchAppliedPatch(commits, &patch, patchID) {
+		case patchApplied:
+			applied++
+		case patchLikelyApplied:
+			likely++
+		}
+	}
+	return applied, likely, len(patches), nil
+}
+
+type patchMatch int
+
+const (
+	patchNotApplied patchMatch = iota
+	// A commit has the same subject and author, but a different patch ID,
+	// e.g. because the patch was edited when applied
+	patchLikelyApplied
+	patchApplied
+)
+
+// matchAppliedPatch looks for a patch in commits. Only the patch ID proves
+// that a patch has been applied.
+func matchAppliedPatch(commits []gitCommit, patch *mboxPatch, patchID string) patchMatch {
+	match := patchNotApplied
+	for _, commit := range commits {
+		if patchID != "" && commit.PatchID == patchID {
+			return patchApplied
+		}
+		if strings.EqualFold(commit.Subject, patch.Subject) && strings.EqualFold(commit.Author, patch.Author) {
+			match = patchLikelyApplied
+		}
+	}
+	return match
+}
+
+// gitCommit is a non-merge commit of the local repository.
+type gitCommit struct {
+	Hash    string
+	Author  string // email address
+	Subject string
+	PatchID string
+}
+
+func loadGitCommits(rev string, since time.Time) ([]gitCommit, error) {
+	sinceArg := "--since=" + since.Format(time.RFC3339)
+
+	out, err := exec.Command("git", "log", "--no-merges", sinceArg, "--format=%H%x00%ae%x00%s", rev, "--").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits of %q: %v", rev, err)
+	}
+
+	diffs, err := exec.Command("git", "log", "--no-merges", sinceArg, "-p", "--no-color", "--no-ext-diff", rev, "--").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commits of %q: %v", rev, err)
+	}
+	patchIDs, err := gitPatchIDs(diffs)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseGitCommits(string(out), patchIDs), nil
+}
+
+// parseGitCommits parses the output of git log with the format
+// "%H%x00%ae%x00%s", and fills in the patch IDs from git patch-id.
+func parseGitCommits(out string, patchIDs [][2]string) []gitCommit {
+	var commits []gitCommit
+	index := make(map[string]int)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.SplitN(line, "\x00", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		index[fields[0]] = len(commits)
+		commits = append(commits, gitCommit{Hash: fields[0], Author: fields[1], Subject: fields[2]})
+	}
+
+	for _, patchID := range patchIDs {
+		if i, ok := index[patchID[1]]; ok {
+			commits[i].PatchID = patchID[0]
+		}
+	}
+	return commits
+}
+
+// gitPatchIDs returns pairs of patch ID and commit hash for the patches in
+// input.
+func gitPatchIDs(input []byte) ([][2]string, error) {
+	patchIDCmd := exec.Command("git", "patch-id", "--stable")
+	patchIDCmd.Stdin = bytes.NewReader(input)
+	patchIDCmd.Stderr = os.Stderr
+	out, err := patchIDCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git patch-id failed: %v", err)
+	}
+
+	var patchIDs [][2]string
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			patchIDs = append(patchIDs, [2]string{fields[0], fields[1]})
+		}
+	}
+	return patchIDs, nil
+}
+
+const patchsetCoverLetterPrefill = `
+
+# Please write the cover letter above. The first line is the subject, the
+# following lines are the body.`
+
+func newListsPatchsetSendCommand() *cobra.Command {
+	var version int
+	var coverLetter, noCoverLetter, autoConfirm bool
+	var output string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		revRange := "@{upstream}..HEAD"
+		if len(args) > 0 {
+			revRange = args[0]
+		}
+
+		name, owner, instance, err := getMailingListName(ctx, cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+		c := createClientWithInstance("lists", cmd, instance)
+
+		b, err := exec.Command("git", "rev-list", "--count", revRange).Output()
+		if err != nil {
+			log.Fatalf("failed to list commits in %q: %v", revRange, err)
+		}
+		count, err := parseInt32(strings.TrimSpace(string(b)))
+		if err != nil {
+			log.Fatal(err)
+		} else if count == 0 {
+			log.Fatalf("no commits in %q", revRange)
+		}
+
+		withCoverLetter := (count > 1 || coverLetter) && !noCoverLetter
+
+		var subject, blurb string
+		if withCoverLetter {
+			text, err := getInputWithEditor("hut_cover-letter*.txt", patchsetCoverLetterPrefill)
+			if err != nil {
+				log.Fatalf("failed to read cover letter: %v", err)
+			}
+			text = strings.TrimSpace(dropComment(text, patchsetCoverLetterPrefill))
+			subject, blurb, _ = strings.Cut(text, "\n")
+			subject = strings.TrimSpace(subject)
+			blurb = strings.TrimSpace(blurb)
+			if subject == "" {
+				log.Println("Aborting due to empty cover letter subject.")
+				os.Exit(1)
+			}
+		} else {
+			b, err := exec.Command("git", "log", "-1", "--format=%s", revRange).Output()
+			if err != nil {
+				log.Fatalf("failed to get commit subject: %v", err)
+			}
+			subject = strings.TrimSpace(string(b))
+		}
+
+		me, err := listssrht.Patches(c.Client, ctx, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if owner == "" {
+			owner = me.CanonicalName
+		}
+
+		previous, err := findPreviousPatchset(ctx, c, me, name, owner, subject)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if version == 0 {
+			version = 1
+			if previous != nil {
+				version = int(previous.Version) + 1
+			}
+		}
+
+		var previousURL string
+		if previous != nil {
+			previousURL = fmt.Sprintf("%s/%s/%s/patches/%d", c.BaseURL, owner, name, previous.Id)
+			log.Printf("Found previous version v%d: %s\n", previous.Version, previousURL)
+		}
+
+		cfg := loadConfig(cmd).Mail
+		from, err := getMailFrom(ctx, cmd, cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		listAddress := fmt.Sprintf("%s/%s@%s", owner, name, stripProtocol(c.BaseURL))
+
+		dir, err := os.MkdirTemp("", "hut-patchset-")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		ident := from.Address
+		if from.Name != "" {
+			ident = fmt.Sprintf("%s <%s>", from.Name, from.Address)
+		}
+		formatArgs := []string{"format-patch", "-o", dir, "--thread=shallow",
+			"--from=" + ident, "--to=" + listAddress}
+		if version > 1 {
+			formatArgs = append(formatArgs, fmt.Sprintf("-v%d", version))
+		}
+		if withCoverLetter {
+			formatArgs = append(formatArgs, "--cover-letter")
+		}
+		formatArgs = append(formatArgs, revRange)
+		formatCmd := exec.Command("git", formatArgs...)
+		formatCmd.Stderr = os.Stderr
+		b, err = formatCmd.Output()
+		if err != nil {
+			log.Fatalf("failed to format patches: %v", err)
+		}
+		files := strings.Fields(string(b))
+
+		if withCoverLetter {
+			if previousURL != "" {
+				blurb = strings.TrimSpace(blurb + "\n\nPrevious version: " + previousURL)
+			}
+			err = rewritePatchFile(files[0], func(s string) string {
+				s = strings.Replace(s, "*** SUBJECT HERE ***", mime.QEncoding.Encode("utf-8", subject), 1)
+				s = strings.Replace(s, "*** BLURB HERE ***", blurb, 1)
+
+				header, body, _ := strings.Cut(s, "\n\n")
+				if !isASCII(blurb) && !strings.Contains(header, "\nContent-Type:") {
+					header += "\nMIME-Version: 1.0\nContent-Type: text/plain; charset=UTF-8\nContent-Transfer-Encoding: 8bit"
+				}
+				return header + "\n\n" + body
+			})
+		} else if previousURL != "" {
+			// Notes after the "---" separator are not part of the commit
+			err = rewritePatchFile(files[0], func(s string) string {
+				return strings.Replace(s, "\n---\n", "\n---\nPrevious version: "+previousURL+"\n\n", 1)
+			})
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var msgs []*mail.Message
+		var raws [][]byte
+		for _, filename := range files {
+			raw, err := os.ReadFile(filename)
+			if err != nil {
+				log.Fatal(err)
+			}
+			// Drop the mbox "From " line
+			if _, rest, ok := bytes.Cut(raw, []byte("\n")); ok && bytes.HasPrefix(raw, []byte("From ")) {
+				raw = rest
+			}
+			msg, err := mail.ReadMessage(bytes.NewReader(raw))
+			if err != nil {
+				log.Fatalf("failed to parse %q: %v", filename, err)
+			}
+			msgs = append(msgs, msg)
+			raws = append(raws, raw)
+		}
+
+		if len(msgs) > 1 && output != "" && output != "-" && !strings.HasSuffix(output, ".mbox") {
+			log.Fatalf("cannot write %d patches to %q: use a file ending in .mbox", len(msgs), output)
+		}
+
+		// Keep stdout for the patches themselves
+		summary := os.Stdout
+		if output == "-" {
+			summary = os.Stderr
+		}
+		dec := new(mime.WordDecoder)
+		for _, msg := range msgs {
+			subject, err := dec.DecodeHeader(msg.Header.Get("Subject"))
+			if err != nil {
+				subject = msg.Header.Get("Subject")
+			}
+			fmt.Fprintln(summary, subject)
+		}
+		if output == "" && !autoConfirm && !getConfirmation(fmt.Sprintf("Send %d emails to %s", len(msgs), listAddress)) {
+			log.Println("Aborted")
+			return
+		}
+
+		for i, msg := range msgs {
+			var rcpts []string
+			for _, key := range []string{"To", "Cc"} {
+				addrs, _ := msg.Header.AddressList(key)
+				for _, addr := range addrs {
+					rcpts = append(rcpts, addr.Address)
+				}
+			}
+			date, err := msg.Header.Date()
+			if err != nil {
+				date = time.Now()
+			}
+			if output == "-" && len(msgs) > 1 {
+				// Several patches on stdout are written as an mbox
+				err = writeMboxMessage(os.Stdout, from.Address, date, raws[i])
+			} else {
+				err = deliverMessage(cfg, from.Address, rcpts, date, raws[i], output)
+			}
+			if err != nil {
+				log.Fatalf("failed to send %q: %v", files[i], err)
+			}
+		}
+
+		if output != "" {
+			return
+		}
+		log.Printf("Sent %d emails to %s\n", len(msgs), listAddress)
+
+		if previous != nil && previous.Status != listssrht.PatchsetStatusSuperseded {
+			_, err := listssrht.UpdatePatchset(c.Client, ctx, previous.Id, listssrht.PatchsetStatusSuperseded)
+			if err != nil {
+				log.Fatalf("failed to mark previous version as superseded: %v", err)
+			}
+			log.Printf("Marked v%d as superseded\n", previous.Version)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "send [revision-range]",
+		Short:             "Send a patchset",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().IntVarP(&version, "version", "v", 0, "patchset version")
+	cmd.RegisterFlagCompletionFunc("version", cobra.NoFileCompletions)
+	cmd.Flags().BoolVar(&coverLetter, "cover-letter", false, "always add a cover letter")
+	cmd.Flags().BoolVar(&noCoverLetter, "no-cover-letter", false, "never add a cover letter")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write the patches to a file instead of sending them")
+	cmd.Flags().BoolVarP(&autoConfirm, "yes", "y", false, "send without confirmation")
+	cmd.MarkFlagsMutuallyExclusive("cover-letter", "no-cover-letter")
+	return cmd
+}
+
+// findPreviousPatchset looks for the latest patchset sent by the user to a
+// mailing list with the provided subject.
+func findPreviousPatchset(ctx context.Context, c *Client, me *listssrht.User, name, owner, subject string) (*listssrht.Patchset, error) {
+	// Only look at the most recent patchsets
+	const maxPages = 5
+
+	patches := me.Patches
+	for i := 0; ; i++ {
+		for _, patchset := range patches.Results {
+			if patchset.List.Name == name && patchset.List.Owner.CanonicalName == owner &&
+				strings.EqualFold(strings.TrimSpace(patchset.Subject), subject) {
+				return &patchset, nil
+			}
+		}
+
+		if patches.Cursor == nil || i+1 >= maxPages {
+			return nil, nil
+		}
+		user, err := listssrht.Patches(c.Client, ctx, patches.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		patches = user.Patches
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func rewritePatchFile(filename string, f func(s string) string) error {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(f(string(b))), 0644)
+}
+
+func newListsPatchsetToolCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tool",
+		Short: "Manage patchset tools",
+	}
+	cmd.AddCommand(newListsPatchsetToolCreateCommand())
+	cmd.AddCommand(newListsPatchsetToolListCommand())
+	cmd.AddCommand(newListsPatchsetToolUpdateCommand())
+	return cmd
+}
+
+func newListsPatchsetToolCreateCommand() *cobra.Command {
+	var icon, details string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		toolIcon, err := listssrht.ParseToolIcon(icon)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		id, instance, err := parsePatchID(ctx, cmd, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		c := createClientWithInstance("lists", cmd, instance)
+
+		tool, err := listssrht.CreateTool(c.Client, ctx, id, details, toolIcon)
+		if err != nil {
+			log.Fatal(err)
+		} else if tool == nil {
+			log.Fatalf("failed to create tool for patchset %d", id)
+		}
+
+		log.Printf("Created tool with ID %d\n", tool.Id)
+	}
+
+	cmd := &cobra.Command{
+		Use:               "create <ID>",
+		Short:             "Create a patchset tool",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePatchsetID,
+		Run:               run,
+	}
+	cmd.Flags().StringVarP(&icon, "icon", "i", "", "tool icon")
+	cmd.RegisterFlagCompletionFunc("icon", completeToolIcon)
+	cmd.MarkFlagRequired("icon")
+	cmd.Flags().StringVarP(&details, "details", "d", "", "tool details")
+	cmd.RegisterFlagCompletionFunc("details", cobra.NoFileCompletions)
+	cmd.MarkFlagRequired("details")
+	return cmd
+}
+
+func newListsPatchsetToolListCommand() *cobra.Command {
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		id, instance, err := parsePatchID(ctx, cmd, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		c := createClientWithInstance("lists", cmd, instance)
+
+		patchset, err := listssrht.PatchsetTools(c.Client, ctx, id)
+		if err != nil {
+			log.Fatal(err)
+		} else if patchset == nil {
+			log.Fatalf("no such patchset %d", id)
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+		defer tw.Flush()
+		for _, tool := range patchset.Tools {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", termfmt.DarkYellow.Sprintf("#%d", tool.Id),
+				tool.Icon.TermString(), tool.Details, termfmt.Dim.String(humanize.Time(tool.Updated.Time)))
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "list <ID>",
+		Short:             "List patchset tools",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePatchsetID,
+		Run:               run,
+	}
+	return cmd
+}
+
+func newListsPatchsetToolUpdateCommand() *cobra.Command {
+	var icon, details string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		c := createClient("lists", cmd)
+
+		id, err := parseInt32(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var toolIcon *listssrht.ToolIcon
+		if icon != "" {
+			i, err := listssrht.ParseToolIcon(icon)
+			if err != nil {
+				log.Fatal(err)
+			}
+			toolIcon = &i
+		}
+
+		var toolDetails *string
+		if cmd.Flags().Changed("details") {
+			toolDetails = &details
+		}
+
+		tool, err := listssrht.UpdateTool(c.Client, ctx, id, toolDetails, toolIcon)
+		if err != nil {
+			log.Fatal(err)
+		} else if tool == nil {
+			log.Fatalf("failed to update tool with ID %d", id)
+		}
+
+		log.Printf("Updated tool with ID %d\n", tool.Id)
+	}
+
+	cmd := &cobra.Command{
+		Use:               "update <tool-ID>",
+		Short:             "Update a patchset tool",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().StringVarP(&icon, "icon", "i", "", "tool icon")
+	cmd.RegisterFlagCompletionFunc("icon", completeToolIcon)
+	cmd.Flags().StringVarP(&details, "details", "d", "", "tool details")
+	cmd.RegisterFlagCompletionFunc("details", cobra.NoFileCompletions)
+	cmd.MarkFlagsOneRequired("icon", "details")
+	return cmd
+}
+
+func getPatchsetMbox(ctx context.Context, c *Client, id int32) (*listssrht.Patchset, error) {
+	patchset, err := listssrht.PatchsetMbox(c.Client, ctx, id)
+	if err != nil {
+		return nil, err
+	} else if patchset == nil {
+		return nil, fmt.Errorf("no such patchset %d", id)
+	}
+	return patchset, nil
+}
+
+// addMboxTrailer adds a trailer to the commit message of each patch of an
+// mbox with git-interpret-trailers(1). The result uses the mboxrd format.
+func addMboxTrailer(mbox string, trailer string) ([]byte, error) {
+	var out bytes.Buffer
+	for _, msg := range readMboxMessages(mbox) {
+		patched, err := addPatchTrailer(msg, trailer)
+		if err != nil {
+			return nil, err
+		}
+
+		from := "MAILER-DAEMON"
+		date := time.Now()
+		if m, err := mail.ReadMessage(strings.NewReader(msg)); err == nil {
+			if addr, err := mail.ParseAddress(m.Header.Get("From")); err == nil {
+				from = addr.Address
+			}
+			if t, err := m.Header.Date(); err == nil {
+				date = t
+			}
+		}
+		if err := writeMboxMessage(&out, from, date, []byte(patched)); err != nil {
+			return nil, err
+		}
+	}
+	return out.Bytes(), nil
+}
+
+var contentTransferEncodingRegexp = regexp.MustCompile(`(?im)^Content-Transfer-Encoding:.*$`)
+
+// addPatchTrailer adds a trailer to the commit message of a patch email,
+// which ends at the "---" line. Messages without a patch are left as is.
+// Encoded bodies are decoded, since git-am(1) accepts 8bit bodies.
+func addPatchTrailer(msg string, trailer string) (string, error) {
+	header, body, ok := strings.Cut(msg, "\n\n")
+	if !ok {
+		return msg, nil
+	}
+
+	m, err := mail.ReadMessage(strings.NewReader(msg))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse message: %v", err)
+	}
+	if mediaType, _, err := mime.ParseMediaType(m.Header.Get("Content-Type")); err == nil && strings.HasPrefix(mediaType, "multipart/") {
+		return "", fmt.Errorf("multipart patch %q is not supported", m.Header.Get("Subject"))
+	}
+
+	if encoding := m.Header.Get("Content-Transfer-Encoding"); encoding != "" {
+		b, err := decodeTransferEncoding(encoding, []byte(body))
+		if err != nil {
+			return "", fmt.Errorf("failed to decode message body: %v", err)
+		}
+		body = string(b)
+		header = contentTransferEncodingRegexp.ReplaceAllString(header, "Content-Transfer-Encoding: 8bit")
+	}
+
+	var commitMsg, patch string
+	if strings.HasPrefix(body, "---\n") {
+		patch = body
+	} else if i := strings.Index(body, "\n---\n"); i >= 0 {
+		commitMsg, patch = body[:i+1], body[i+1:]
+	} else {
+		return msg, nil
+	}
+
+	cmd := exec.Command("git", "interpret-trailers", "--trailer", trailer)
+	cmd.Stdin = strings.NewReader(commitMsg)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git interpret-trailers failed: %v", err)
+	}
+
+	return header + "\n\n" + string(out) + patch, nil
+}
+
+var errPatchsetApply = errors.New("patchset does not apply")
+
+// withPatchedWorktree applies a patchset on base in a scratch worktree, and
+// calls fn with the path of the worktree. The worktree is removed afterwards.
+func withPatchedWorktree(base string, mbox []byte, fn func(dir string) error) error {
+	dir, err := os.MkdirTemp("", "hut-patchset-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := runGitCommand("worktree", "add", "--detach", dir, base); err != nil {
+		return fmt.Errorf("failed to create worktree: %v", err)
+	}
+	defer runGitCommand("worktree", "remove", "--force", dir)
+
+	amCmd := exec.Command("git", "-C", dir, "-c", "user.name=hut", "-c", "user.email=hut@localhost", "am", "-3")
+	amCmd.Stdin = bytes.NewReader(mbox)
+	amCmd.Stdout = os.Stderr
+	amCmd.Stderr = os.Stderr
+	var exitErr *exec.ExitError
+	if err := amCmd.Run(); errors.As(err, &exitErr) {
+		return errPatchsetApply
+	} else if err != nil {
+		return fmt.Errorf("failed to run git am: %v", err)
+	}
+
+	return fn(dir)
+}
+
+func fetchListsFile(ctx context.Context, c *Client, url string, w io.Writer) error {
+	body, err := openListsFile(ctx, c, url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if _, err := io.Copy(w, body); err != nil {
+		return fmt.Errorf("failed to copy response body: %v", err)
+	}
+	return nil
+}
+
+// openListsFile is like fetchListsFile, but returns the response body. The
+// caller must close it.
+func openListsFile(ctx context.Context, c *Client, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("invalid HTTP status: %v", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// writeCoverLetter writes the raw cover letter as an mbox entry.
+func writeCoverLetter(ctx context.Context, c *Client, w io.Writer, email *listssrht.Email) error {
+	var raw bytes.Buffer
+	if err := fetchListsFile(ctx, c, string(email.Envelope), &raw); err != nil {
+		return fmt.Errorf("failed to fetch cover letter: %v", err)
+	}
+
+	fmt.Fprintf(w, "From nobody %s\n", email.Date.Format(dateLayout))
+
+	text := strings.ReplaceAll(raw.String(), "\r\n", "\n")
+	for _, line := range strings.SplitAfter(strings.TrimRight(text, "\n"), "\n") {
+		// Quote lines which would be mistaken for a message separator
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			line = ">" + line
+		}
+		io.WriteString(w, line)
+	}
+	_, err := io.WriteString(w, "\n\n")
+	return err
+}
+
+func newListsReplyCommand() *cobra.Command {
+	var stdin bool
+	var output string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		messageID, instance, err := parseMessageID(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		c := createClientWithInstance("lists", cmd, instance)
+
+		email, err := listssrht.MessageByID(c.Client, ctx, messageID)
+		if err != nil {
+			log.Fatal(err)
+		} else if email == nil {
+			log.Fatalf("no such message %s", messageID)
+		}
+
+		var raw bytes.Buffer
+		if err := fetchListsFile(ctx, c, string(email.Envelope), &raw); err != nil {
+			log.Fatalf("failed to fetch message: %v", err)
+		}
+		parent, err := mail.ReadMessage(&raw)
+		if err != nil {
+			log.Fatalf("failed to parse message: %v", err)
+		}
+
+		cfg := loadConfig(cmd).Mail
+		from, err := getMailFrom(ctx, cmd, cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		listAddress := &mail.Address{Address: fmt.Sprintf("%s/%s@%s",
+			email.List.Owner.CanonicalName, email.List.Name, stripProtocol(c.BaseURL))}
+		reply := newReplyMail(parent.Header, from, listAddress, messageID, email.Subject)
+
+		var body string
+		if stdin {
+			b, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				log.Fatalf("failed to read reply: %v", err)
+			}
+			body = string(b)
+		} else {
+			quote := quoteReply(parent.Header, email)
+			body, err = getInputWithEditor("hut_reply*.eml", quote)
+			if err != nil {
+				log.Fatalf("failed to read reply: %v", err)
+			}
+			if strings.TrimSpace(body) == strings.TrimSpace(quote) {
+				body = ""
+			}
+		}
+		if strings.TrimSpace(body) == "" {
+			log.Println("Aborting due to empty reply.")
+			os.Exit(1)
+		}
+		reply.Body = body
+
+		if output == "" && isStdinTerminal {
+			fmt.Printf("To: %s\n", formatAddressList(reply.To))
+			if len(reply.Cc) > 0 {
+				fmt.Printf("Cc: %s\n", formatAddressList(reply.Cc))
+			}
+			fmt.Printf("Subject: %s\n", reply.Subject)
+			if !getConfirmation("Send reply") {
+				log.Println("Aborted")
+				return
+			}
+		}
+
+		if err := deliverMail(cfg, reply, output); err != nil {
+			log.Fatal(err)
+		}
+		if output == "" {
+			log.Printf("Sent reply to %q\n", email.Subject)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "reply <message-id>",
+		Short:             "Reply to an email",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().BoolVar(&stdin, "stdin", !isStdinTerminal, "read reply from stdin")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write the reply to a file instead of sending it")
+	return cmd
+}
+
+// newReplyMail creates a reply to all recipients of an email, including the
+// mailing list.
+func newReplyMail(parent mail.Header, from, list *mail.Address, messageID, subject string) *outgoingMail {
+	to, err := parent.AddressList("Reply-To")
+	if err != nil || len(to) == 0 {
+		to, _ = parent.AddressList("From")
+	}
+
+	seen := map[string]bool{strings.ToLower(from.Address): true}
+	for _, addr := range to {
+		seen[strings.ToLower(addr.Address)] = true
+	}
+
+	var addrs []*mail.Address
+	for _, key := range []string{"To", "Cc"} {
+		l, _ := parent.AddressList(key)
+		addrs = append(addrs, l...)
+	}
+	addrs = append(addrs, list)
+
+	var cc []*mail.Address
+	for _, addr := range addrs {
+		if k := strings.ToLower(addr.Address); !seen[k] {
+			seen[k] = true
+			cc = append(cc, addr)
+		}
+	}
+
+	if !strings.HasPrefix(strings.ToLower(subject), "re:") {
+		subject = "Re: " + subject
+	}
+
+	references := strings.Fields(parent.Get("References"))
+	if len(references) == 0 && parent.Get("In-Reply-To") != "" {
+		references = strings.Fields(parent.Get("In-Reply-To"))
+	}
+	references = append(references, messageID)
+
+	return &outgoingMail{
+		From:       from,
+		To:         to,
+		Cc:         cc,
+		Subject:    subject,
+		Date:       time.Now(),
+		MessageID:  generateMessageID(from),
+		InReplyTo:  messageID,
+		References: references,
+	}
+}
+
+func quoteReply(parent mail.Header, email *listssrht.Email) string {
+	author := parent.Get("From")
+	if addr, err := mail.ParseAddress(author); err == nil {
+		author = addr.Name
+		if author == "" {
+			author = addr.Address
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "On %s, %s wrote:\n", email.Date.Format(dateLayout), author)
+	body := strings.TrimRight(strings.ReplaceAll(email.Body, "\r\n", "\n"), "\n")
+	for _, line := range strings.Split(body, "\n") {
+		if line == "" || strings.HasPrefix(line, ">") {
+			sb.WriteString(">" + line + "\n")
+		} else {
+			sb.WriteString("> " + line + "\n")
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func newListsThreadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "thread",
+		Short: "Read threads",
+	}
+	cmd.AddCommand(newListsThreadListCommand())
+	cmd.AddCommand(newListsThreadShowCommand())
+	return cmd
+}
+
+func newListsThreadListCommand() *cobra.Command {
+	var count int
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		var name, owner, instance string
+		if len(args) > 0 {
+			name, owner, instance = parseMailingListName(args[0])
+		} else {
+			var err error
+			name, owner, instance, err = getMailingListName(ctx, cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		c := createClientWithInstance("lists", cmd, instance)
+
+		var (
+			cursor   *listssrht.Cursor
+			user     *listssrht.User
+			username string
+			err      error
+		)
+		if owner != "" {
+			username = strings.TrimLeft(owner, ownerPrefixes)
+		}
+
+		err = pagerify(func(p pager) error {
+			if username != "" {
+				user, err = listssrht.ThreadsByUser(c.Client, ctx, username, name, cursor)
+			} else {
+				user, err = listssrht.Threads(c.Client, ctx, name, cursor)
+			}
+
+			if err != nil {
+				return err
+			} else if user == nil {
+				return fmt.Errorf("no such user %q", username)
+			} else if user.List == nil {
+				return fmt.Errorf("no such list %q", name)
+			}
+
+			tw := tabwriter.NewWriter(p, 0, 2, 2, ' ', 0)
+			defer tw.Flush()
+			for _, thread := range user.List.Threads.Results {
+				printThreadSummary(tw, &thread)
+			}
+
+			cursor = user.List.Threads.Cursor
+			if p.IsDone(cursor, len(user.List.Threads.Results)) {
+				return pagerDone
+			}
+
+			return nil
+		}, count)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "list [list]",
+		Short:             "List threads",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeList,
+		Run:               run,
+	}
+	cmd.Flags().IntVar(&count, "count", 0, "number of threads to fetch")
+	cmd.RegisterFlagCompletionFunc("count", cobra.NoFileCompletions)
+	return cmd
+}
+
+func printThreadSummary(w io.Writer, thread *listssrht.Thread) {
+	replies := "1 reply"
+	if thread.Replies != 1 {
+		replies = fmt.Sprintf("%d replies", thread.Replies)
+	}
+	participants := "1 participant"
+	if thread.Participants != 1 {
+		participants = fmt.Sprintf("%d participants", thread.Participants)
+	}
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s, %s\t%s\n", termfmt.DarkYellow.Sprintf("#%d", thread.Root.Id),
+		thread.Subject, thread.Sender.CanonicalName, replies, participants,
+		termfmt.Dim.String(humanize.Time(thread.Updated.Time)))
+}
+
+func newListsThreadShowCommand() *cobra.Command {
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		id, messageID, instance, err := parseThreadID(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		c := createClientWithInstance("lists", cmd, instance)
+
+		var (
+			cursor *listssrht.Cursor
+			thread *listssrht.Thread
+			emails []listssrht.Email
+		)
+		for {
+			var email *listssrht.Email
+			if messageID != "" {
+				email, err = listssrht.ThreadByMessage(c.Client, ctx, messageID, cursor)
+			} else {
+				email, err = listssrht.ThreadByEmail(c.Client, ctx, id, cursor)
+			}
+			if err != nil {
+				log.Fatal(err)
+			} else if email == nil {
+				log.Fatalf("no such thread %q", args[0])
+			}
+
+			thread = email.Thread
+			emails = append(emails, thread.Descendants.Results...)
+
+			cursor = thread.Descendants.Cursor
+			if cursor == nil {
+				break
+			}
+		}
+
+		err = pagerify(func(p pager) error {
+			printThread(p, thread, emails)
+			return pagerDone
+		}, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "show <ID>",
+		Short:             "Show a thread",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	return cmd
+}
+
+// parseThreadID parses a thread identifier: either the ID of its root email,
+// or the Message-ID of any of its emails.
+func parseThreadID(s string) (id int32, messageID, instance string, err error) {
+	if strings.Contains(s, "@") {
+		return 0, "<" + strings.Trim(s, "<>") + ">", "", nil
+	}
+
+	s, _, instance = parseResourceName(s)
+	split := strings.Split(s, "/")
+	id, err = parseInt32(strings.TrimPrefix(split[len(split)-1], "#"))
+	if err != nil {
+		return 0, "", "", fmt.Errorf("invalid thread ID: %v", err)
+	}
+	return id, "", instance, nil
+}
+
+// parseMessageID parses a Message-ID, optionally given as the URL of the
+// email in the archive. The returned Message-ID has angle brackets.
+func parseMessageID(s string) (messageID, instance string, err error) {
+	if !strings.HasPrefix(s, "<") && strings.Contains(s, "/") {
+		s, _, instance = parseResourceName(s)
+		s = s[strings.LastIndex(s, "/")+1:]
+		if s, err = url.PathUnescape(s); err != nil {
+			return "", "", fmt.Errorf("invalid message ID: %v", err)
+		}
+	}
+
+	s = strings.Trim(s, "<>")
+	if s == "" {
+		return "", "", errors.New("empty message ID")
+	}
+	return "<" + s + ">", instance, nil
+}
+
+// printThread prints a thread as a tree of emails, indented by depth.
+func printThread(w io.Writer, thread *listssrht.Thread, emails []listssrht.Email) {
+	children := make(map[int32][]*listssrht.Email)
+	known := map[int32]bool{thread.Root.Id: true}
+	for i := range emails {
+		known[emails[i].Id] = true
+	}
+	for i := range emails {
+		email := &emails[i]
+		parent := thread.Root.Id
+		if email.Parent != nil && known[email.Parent.Id] {
+			parent = email.Parent.Id
+		}
+		children[parent] = append(children[parent], email)
+	}
 
-			for _, patch := range patchset.Patches.Results {
-				formatPatch(&mbox, &patch)
-			}
+	fmt.Fprintln(w, termfmt.Bold.String(thread.Subject))
+	fmt.Fprintln(w)
+	printThreadEmail(w, thread.Root, children, 0)
+}
 
-			cursor = patchset.Patches.Cursor
-			if cursor == nil {
-				break
-			}
-		}
+func printThreadEmail(w io.Writer, email *listssrht.Email, children map[int32][]*listssrht.Email, depth int) {
+	prefix := strings.Repeat("  ", depth)
 
-		applyCmd := exec.Command("git", "am", "-3")
-		applyCmd.Stdin = &mbox
-		applyCmd.Stdout = os.Stdout
-		applyCmd.Stderr = os.Stderr
+	fmt.Fprintf(w, "%s%s %s %s\n", prefix, termfmt.DarkYellow.Sprintf("#%d", email.Id),
+		termfmt.Bold.String(email.Sender.CanonicalName), termfmt.Dim.String(humanize.Time(email.Date.Time)))
+	body := strings.TrimSpace(foldQuotes(email.Body))
+	if body != "" {
+		fmt.Fprintln(w, indent(body, prefix+"  "))
+	}
+	fmt.Fprintln(w)
 
-		if err := applyCmd.Run(); err != nil {
-			log.Fatal(err)
+	for _, child := range children[email.Id] {
+		printThreadEmail(w, child, children, depth+1)
+	}
+}
+
+// foldQuotes replaces blocks of quoted lines in an email body with a
+// placeholder. Short quotes are kept.
+func foldQuotes(body string) string {
+	const maxQuoteLines = 2
+
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	var out, quote []string
+	flush := func() {
+		if len(quote) > maxQuoteLines {
+			out = append(out, termfmt.Dim.Sprintf("[... %d quoted lines]", len(quote)))
+		} else {
+			for _, line := range quote {
+				out = append(out, termfmt.Dim.String(line))
+			}
 		}
+		quote = nil
 	}
 
-	cmd := &cobra.Command{
-		Use:               "apply <ID>",
-		Short:             "Apply a patchset",
-		Args:              cobra.ExactArgs(1),
-		ValidArgsFunction: completePatchsetID,
-		Run:               run,
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimLeft(line, " "), ">") {
+			quote = append(quote, line)
+			continue
+		}
+		flush()
+		out = append(out, line)
 	}
-	return cmd
-}
+	flush()
 
-func formatPatch(w io.Writer, email *listssrht.Email) {
-	fmt.Fprintf(w, "From nobody %s\n", email.Date.Format(dateLayout))
-	fmt.Fprintf(w, "From: %s\n", email.Header[0])
-	fmt.Fprintf(w, "Subject: %s\n", email.Subject)
-	fmt.Fprintf(w, "Date: %s\n\n", email.Date.Format(dateLayout))
-	io.WriteString(w, strings.ReplaceAll(email.Body, "\r\n", "\n"))
+	return strings.Join(out, "\n")
 }
 
 func newListsACLCommand() *cobra.Command {
@@ -759,7 +2542,10 @@ func newListsACLCommand() *cobra.Command {
 		Short: "Manage access-control lists",
 	}
 	cmd.AddCommand(newListsACLListCommand())
+	cmd.AddCommand(newListsACLUpdateCommand())
+	cmd.AddCommand(newListsACLDefaultCommand())
 	cmd.AddCommand(newListsACLDeleteCommand())
+	cmd.AddCommand(newListsACLApplyCommand())
 	return cmd
 }
 
@@ -852,6 +2638,149 @@ func printListsACLEntry(w io.Writer, acl *listssrht.MailingListACL) {
 		acl.Entity.CanonicalName, s, created)
 }
 
+func newListsACLUpdateCommand() *cobra.Command {
+	var input listssrht.ACLInput
+	var permissions []string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		if err := parseListsACLFlags(cmd, &input, permissions); err != nil {
+			log.Fatal(err)
+		}
+
+		name, owner, instance, err := getMailingListName(ctx, cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		c := createClientWithInstance("lists", cmd, instance)
+		id, err := getMailingListID(c, ctx, name, owner)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var acl *listssrht.MailingListACL
+		if strings.Contains(args[0], "@") && strings.IndexAny(args[0], ownerPrefixes) != 0 {
+			acl, err = listssrht.UpdateSenderACL(c.Client, ctx, id, args[0], input)
+		} else {
+			var user *listssrht.User
+			user, err = listssrht.UserIDByName(c.Client, ctx, strings.TrimLeft(args[0], ownerPrefixes))
+			if err != nil {
+				log.Fatalf("failed to get user ID: %v", err)
+			} else if user == nil {
+				log.Fatalf("no such user %q", args[0])
+			}
+			acl, err = listssrht.UpdateUserACL(c.Client, ctx, id, user.Id, input)
+		}
+		if err != nil {
+			log.Fatal(err)
+		} else if acl == nil {
+			log.Fatalf("failed to update access rights for %q", args[0])
+		}
+
+		log.Printf("Updated access rights for %q\n", acl.Entity.CanonicalName)
+	}
+
+	cmd := &cobra.Command{
+		Use:               "update <user|email>",
+		Short:             "Update/add ACL entries",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	addListsACLFlags(cmd, &input, &permissions)
+	return cmd
+}
+
+func newListsACLDefaultCommand() *cobra.Command {
+	var input listssrht.ACLInput
+	var permissions []string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		if err := parseListsACLFlags(cmd, &input, permissions); err != nil {
+			log.Fatal(err)
+		}
+
+		var name, owner, instance string
+		if len(args) > 0 {
+			name, owner, instance = parseMailingListName(args[0])
+		} else {
+			var err error
+			name, owner, instance, err = getMailingListName(ctx, cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClientWithInstance("lists", cmd, instance)
+		id, err := getMailingListID(c, ctx, name, owner)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		list, err := listssrht.UpdateMailingListACL(c.Client, ctx, id, input)
+		if err != nil {
+			log.Fatal(err)
+		} else if list == nil {
+			log.Fatalf("failed to update default access rights for %q", name)
+		}
+
+		log.Printf("Updated default access rights for %q\n", list.Name)
+	}
+
+	cmd := &cobra.Command{
+		Use:               "default [list]",
+		Short:             "Update default ACL",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeList,
+		Run:               run,
+	}
+	addListsACLFlags(cmd, &input, &permissions)
+	return cmd
+}
+
+var listsACLPermissions = []string{"browse", "reply", "post", "moderate"}
+
+func addListsACLFlags(cmd *cobra.Command, input *listssrht.ACLInput, permissions *[]string) {
+	cmd.Flags().BoolVar(&input.Browse, "browse", false, "permission to browse the list")
+	cmd.Flags().BoolVar(&input.Reply, "reply", false, "permission to reply to threads")
+	cmd.Flags().BoolVar(&input.Post, "post", false, "permission to start new threads")
+	cmd.Flags().BoolVar(&input.Moderate, "moderate", false, "permission to moderate the list")
+	cmd.Flags().StringSliceVarP(permissions, "permissions", "p", nil, "permissions (browse, reply, post, moderate or none)")
+	cmd.RegisterFlagCompletionFunc("permissions", completeListsACLPermissions)
+}
+
+// parseListsACLFlags merges the --permissions flag into input. At least one
+// permission flag must be set, so that all rights aren't removed by accident.
+func parseListsACLFlags(cmd *cobra.Command, input *listssrht.ACLInput, permissions []string) error {
+	changed := false
+	for _, name := range append([]string{"permissions"}, listsACLPermissions...) {
+		changed = changed || cmd.Flags().Changed(name)
+	}
+	if !changed {
+		return errors.New("no permissions specified (use --permissions none to remove all rights)")
+	}
+
+	permissions, err := aclSetPermissions(listsACLPermissions...)(permissions)
+	if err != nil {
+		return err
+	}
+	for _, p := range permissions {
+		switch p {
+		case "browse":
+			input.Browse = true
+		case "reply":
+			input.Reply = true
+		case "post":
+			input.Post = true
+		case "moderate":
+			input.Moderate = true
+		}
+	}
+	return nil
+}
+
 func newListsACLDeleteCommand() *cobra.Command {
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
@@ -882,6 +2811,137 @@ func newListsACLDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func newListsACLApplyCommand() *cobra.Command {
+	var filename string
+	var prune, dryRun bool
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		resources, err := readACLFile(filename, "list", aclSetPermissions(listsACLPermissions...))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, resource := range resources {
+			var name, owner, instance string
+			if resource.Name != "" {
+				name, owner, instance = parseMailingListName(resource.Name)
+			} else if len(args) > 0 {
+				name, owner, instance = parseMailingListName(args[0])
+			} else {
+				name, owner, instance, err = getMailingListName(ctx, cmd)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			c := createClientWithInstance("lists", cmd, instance)
+			backend := newListsACLBackend(c, name, owner)
+			if err := applyACL(ctx, formatResourceName(name, owner), backend, resource.Entries, prune, dryRun); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "apply [list]",
+		Short:             "Apply ACL entries from a file",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeList,
+		Run:               run,
+	}
+	cmd.Flags().StringVarP(&filename, "file", "f", "", "ACL file")
+	cmd.MarkFlagRequired("file")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete entries not listed in the file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the changes")
+	return cmd
+}
+
+func newListsACLBackend(c *Client, name, owner string) *aclBackend {
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	var listID int32
+	return &aclBackend{
+		List: func(ctx context.Context) ([]aclEntry, error) {
+			var (
+				entries []aclEntry
+				cursor  *listssrht.Cursor
+				user    *listssrht.User
+				err     error
+			)
+			for {
+				if username != "" {
+					user, err = listssrht.AclByUser(c.Client, ctx, username, name, cursor)
+				} else {
+					user, err = listssrht.AclByListName(c.Client, ctx, name, cursor)
+				}
+
+				if err != nil {
+					return nil, err
+				} else if user == nil {
+					return nil, fmt.Errorf("no such user %q", username)
+				} else if user.List == nil {
+					return nil, fmt.Errorf("no such list %q", name)
+				}
+
+				for _, acl := range user.List.Acl.Results {
+					var permissions []string
+					if acl.Browse {
+						permissions = append(permissions, "browse")
+					}
+					if acl.Reply {
+						permissions = append(permissions, "reply")
+					}
+					if acl.Post {
+						permissions = append(permissions, "post")
+					}
+					if acl.Moderate {
+						permissions = append(permissions, "moderate")
+					}
+					entries = append(entries, aclEntry{ID: acl.Id, Entity: acl.Entity.CanonicalName, Permissions: permissions})
+				}
+
+				cursor = user.List.Acl.Cursor
+				if cursor == nil {
+					return entries, nil
+				}
+			}
+		},
+		Update: func(ctx context.Context, entity string, permissions []string) error {
+			if listID == 0 {
+				id, err := getMailingListID(c, ctx, name, owner)
+				if err != nil {
+					return err
+				}
+				listID = id
+			}
+
+			user, err := listssrht.UserIDByName(c.Client, ctx, strings.TrimLeft(entity, ownerPrefixes))
+			if err != nil {
+				return err
+			} else if user == nil {
+				return fmt.Errorf("no such user %q", entity)
+			}
+
+			input := listssrht.ACLInput{
+				Browse:   sliceContains(permissions, "browse"),
+				Reply:    sliceContains(permissions, "reply"),
+				Post:     sliceContains(permissions, "post"),
+				Moderate: sliceContains(permissions, "moderate"),
+			}
+			_, err = listssrht.UpdateUserACL(c.Client, ctx, listID, user.Id, input)
+			return err
+		},
+		Delete: func(ctx context.Context, id int32) error {
+			_, err := listssrht.DeleteACL(c.Client, ctx, id)
+			return err
+		},
+	}
+}
+
 func newListsUserWebhookCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "user-webhook",
@@ -890,16 +2950,16 @@ func newListsUserWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newListsUserWebhookCreateCommand())
 	cmd.AddCommand(newListsUserWebhookListCommand())
 	cmd.AddCommand(newListsUserWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadListsUserWebhookDeliveries, loadListsUserWebhookSample, nil)
 	return cmd
 }
 
 func newListsUserWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		c := createClient("lists", cmd)
 
 		var config listssrht.UserWebhookInput
 		config.Url = url
@@ -910,6 +2970,19 @@ func newListsUserWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("lists", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("lists", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClient("lists", cmd)
 
 		webhook, err := listssrht.CreateUserWebhook(c.Client, ctx, config)
 		if err != nil {
@@ -935,7 +3008,10 @@ func newListsUserWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -1007,6 +3083,50 @@ func newListsUserWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadListsUserWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	c := createClient("lists", cmd)
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		webhook, err := listssrht.UserWebhookDeliveries(c.Client, ctx, id, (*listssrht.Cursor)(cursor))
+		if err != nil {
+			return nil, nil, err
+		} else if webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertListsWebhookDeliveries(webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadListsUserWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	c := createClient("lists", cmd)
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		webhook, err := listssrht.UserWebhookSample(c.Client, ctx, id, listssrht.WebhookEvent(event))
+		if err != nil {
+			return "", err
+		} else if webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return webhook.Sample, nil
+	}
+}
+
+func convertListsWebhookDeliveries(cursor *listssrht.WebhookDeliveryCursor) ([]webhookDelivery, *string) {
+	var deliveries []webhookDelivery
+	for _, delivery := range cursor.Results {
+		deliveries = append(deliveries, webhookDelivery{
+			UUID:            delivery.Uuid,
+			Date:            delivery.Date.Time,
+			Event:           string(delivery.Event),
+			RequestBody:     delivery.RequestBody,
+			ResponseBody:    delivery.ResponseBody,
+			ResponseHeaders: delivery.ResponseHeaders,
+			ResponseStatus:  delivery.ResponseStatus,
+		})
+	}
+	return deliveries, (*string)(cursor.Cursor)
+}
+
 func newListsWebhookCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "webhook",
@@ -1015,12 +3135,13 @@ func newListsWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newListsWebhookCreateCommand())
 	cmd.AddCommand(newListsWebhookListCommand())
 	cmd.AddCommand(newListsWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadListsWebhookDeliveries, loadListsWebhookSample, nil)
 	return cmd
 }
 
 func newListsWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
@@ -1051,6 +3172,17 @@ func newListsWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("lists", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("lists", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
 
 		webhook, err := listssrht.CreateMailingListWebhook(c.Client, ctx, id, config)
 		if err != nil {
@@ -1076,7 +3208,10 @@ func newListsWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -1179,6 +3314,80 @@ func newListsWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadListsWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	name, owner, instance, err := getMailingListName(cmd.Context(), cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := createClientWithInstance("lists", cmd, instance)
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		var (
+			user *listssrht.User
+			err  error
+		)
+		if username != "" {
+			user, err = listssrht.MailingListWebhookDeliveriesByUser(c.Client, ctx, username, name, id, (*listssrht.Cursor)(cursor))
+		} else {
+			user, err = listssrht.MailingListWebhookDeliveries(c.Client, ctx, name, id, (*listssrht.Cursor)(cursor))
+		}
+
+		if err != nil {
+			return nil, nil, err
+		} else if user == nil {
+			return nil, nil, fmt.Errorf("no such user %q", username)
+		} else if user.List == nil {
+			return nil, nil, fmt.Errorf("no such mailing list %q", name)
+		} else if user.List.Webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertListsWebhookDeliveries(user.List.Webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadListsWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	name, owner, instance, err := getMailingListName(cmd.Context(), cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := createClientWithInstance("lists", cmd, instance)
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		var (
+			user *listssrht.User
+			err  error
+		)
+		if username != "" {
+			user, err = listssrht.MailingListWebhookSampleByUser(c.Client, ctx, username, name, id, listssrht.WebhookEvent(event))
+		} else {
+			user, err = listssrht.MailingListWebhookSample(c.Client, ctx, name, id, listssrht.WebhookEvent(event))
+		}
+
+		if err != nil {
+			return "", err
+		} else if user == nil {
+			return "", fmt.Errorf("no such user %q", username)
+		} else if user.List == nil {
+			return "", fmt.Errorf("no such mailing list %q", name)
+		} else if user.List.Webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return user.List.Webhook.Sample, nil
+	}
+}
+
 func newListsSubscriptions() *cobra.Command {
 	var count int
 	run := func(cmd *cobra.Command, args []string) {
@@ -1348,6 +3557,14 @@ func parsePatchID(ctx context.Context, cmd *cobra.Command, s string) (id int32,
 	return id, instance, nil
 }
 
+var completeToolIcon = cobra.FixedCompletions([]string{
+	"pending",
+	"waiting",
+	"success",
+	"failed",
+	"cancelled",
+}, cobra.ShellCompDirectiveNoFileComp)
+
 var completePatchsetStatus = cobra.FixedCompletions([]string{
 	"unknown",
 	"proposed",
@@ -1461,6 +3678,17 @@ func completeList(cmd *cobra.Command, args []string, toComplete string) ([]strin
 	return listsList, cobra.ShellCompDirectiveNoFileComp
 }
 
+func completeListsACLPermissions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var permissionList []string
+	set := strings.ToLower(cmd.Flag("permissions").Value.String())
+	for _, permission := range append(listsACLPermissions, "none") {
+		if !strings.Contains(set, permission) {
+			permissionList = append(permissionList, permission)
+		}
+	}
+	return permissionList, cobra.ShellCompDirectiveNoFileComp
+}
+
 func completeMailingListWebhookEvents(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	var eventList []string
 	events := [4]string{"list_updated", "list_deleted", "email_received", "patchset_received"}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"net/mail"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+
+	"git.sr.ht/~emersion/gqlclient"
+
+	"git.sr.ht/~xenrox/hut/srht/listssrht"
+)
+
+func TestFoldQuotes(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"Hello\n\nThanks!", "Hello\n\nThanks!"},
+		{"Hi\n> short\n> quote\nOK", "Hi\n> short\n> quote\nOK"},
+		{"Hi\n> a\n> b\n>> c\nOK", "Hi\n[... 3 quoted lines]\nOK"},
+		{"Hi\r\n > a\r\n > b\r\n > c\r\n", "Hi\n[... 3 quoted lines]\n"},
+		{"> a\n> b\n> c", "[... 3 quoted lines]"},
+	}
+
+	for _, test := range tests {
+		got := foldQuotes(test.body)
+		if got != test.want {
+			t.Errorf("foldQuotes(%q): expected %q, got %q", test.body, test.want, got)
+		}
+	}
+}
+
+func TestQuoteReply(t *testing.T) {
+	date := gqlclient.Time{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	tests := []struct {
+		from string
+		body string
+		want string
+	}{
+		{
+			"Alice <alice@example.org>",
+			"Hello\r\n\r\n> earlier\r\n",
+			"On Tue, 02 Jan 2024 03:04:05 +0000, Alice wrote:\n> Hello\n>\n>> earlier\n\n",
+		},
+		{
+			"bob@example.org",
+			"Hi\n\n\n",
+			"On Tue, 02 Jan 2024 03:04:05 +0000, bob@example.org wrote:\n> Hi\n\n",
+		},
+	}
+
+	for _, test := range tests {
+		parent := mail.Header{"From": {test.from}}
+		got := quoteReply(parent, &listssrht.Email{Date: date, Body: test.body})
+		if got != test.want {
+			t.Errorf("quoteReply(%q): expected %q, got %q", test.body, test.want, got)
+		}
+	}
+}
+
+func TestNewReplyMail(t *testing.T) {
+	from := &mail.Address{Address: "me@example.org"}
+	list := &mail.Address{Address: "~alice/foo@lists.example.org"}
+
+	tests := []struct {
+		name       string
+		parent     mail.Header
+		subject    string
+		to         []string
+		cc         []string
+		wantSubj   string
+		references []string
+	}{
+		{
+			name: "reply to sender",
+			parent: mail.Header{
+				"From": {"Alice <alice@example.org>"},
+				"To":   {"~alice/foo@lists.example.org"},
+			},
+			subject:    "Add foo",
+			to:         []string{"alice@example.org"},
+			cc:         []string{"~alice/foo@lists.example.org"},
+			wantSubj:   "Re: Add foo",
+			references: []string{"<1@example.org>"},
+		},
+		{
+			name: "reply-to and cc",
+			parent: mail.Header{
+				"From":        {"alice@example.org"},
+				"Reply-To":    {"bob@example.org"},
+				"To":          {"me@example.org, ~alice/foo@lists.example.org"},
+				"Cc":          {"Carol <CAROL@example.org>, bob@example.org"},
+				"In-Reply-To": {"<0@example.org>"},
+			},
+			subject:    "RE: Add foo",
+			to:         []string{"bob@example.org"},
+			cc:         []string{"~alice/foo@lists.example.org", "CAROL@example.org"},
+			wantSubj:   "RE: Add foo",
+			references: []string{"<0@example.org>", "<1@example.org>"},
+		},
+		{
+			name: "references",
+			parent: mail.Header{
+				"From":        {"alice@example.org"},
+				"References":  {"<a@example.org> <b@example.org>"},
+				"In-Reply-To": {"<b@example.org>"},
+			},
+			subject:    "Re: Add foo",
+			to:         []string{"alice@example.org"},
+			cc:         []string{"~alice/foo@lists.example.org"},
+			wantSubj:   "Re: Add foo",
+			references: []string{"<a@example.org>", "<b@example.org>", "<1@example.org>"},
+		},
+	}
+
+	addresses := func(l []*mail.Address) []string {
+		var s []string
+		for _, addr := range l {
+			s = append(s, addr.Address)
+		}
+		return s
+	}
+
+	for _, test := range tests {
+		m := newReplyMail(test.parent, from, list, "<1@example.org>", test.subject)
+		if got := addresses(m.To); !reflect.DeepEqual(got, test.to) {
+			t.Errorf("newReplyMail(%s) To: expected %q, got %q", test.name, test.to, got)
+		}
+		if got := addresses(m.Cc); !reflect.DeepEqual(got, test.cc) {
+			t.Errorf("newReplyMail(%s) Cc: expected %q, got %q", test.name, test.cc, got)
+		}
+		if m.Subject != test.wantSubj {
+			t.Errorf("newReplyMail(%s) Subject: expected %q, got %q", test.name, test.wantSubj, m.Subject)
+		}
+		if m.InReplyTo != "<1@example.org>" {
+			t.Errorf("newReplyMail(%s) In-Reply-To: expected %q, got %q", test.name, "<1@example.org>", m.InReplyTo)
+		}
+		if !reflect.DeepEqual(m.References, test.references) {
+			t.Errorf("newReplyMail(%s) References: expected %q, got %q", test.name, test.references, m.References)
+		}
+	}
+}
+
+func TestAddPatchTrailer(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	const diff = "---\n foo | 1 +\n\ndiff --git a/foo b/foo\n"
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{
+			name: "body",
+			msg:  "Subject: [PATCH] Add foo\n\nSome details\n" + diff,
+			want: "Subject: [PATCH] Add foo\n\nSome details\n\nLink: https://example.org\n" + diff,
+		},
+		{
+			name: "existing trailer",
+			msg:  "Subject: [PATCH] Add foo\n\nSome details\n\nSigned-off-by: Alice <alice@example.org>\n" + diff,
+			want: "Subject: [PATCH] Add foo\n\nSome details\n\nSigned-off-by: Alice <alice@example.org>\nLink: https://example.org\n" + diff,
+		},
+		{
+			name: "no body",
+			msg:  "Subject: [PATCH] Add foo\n\n" + diff,
+			want: "Subject: [PATCH] Add foo\n\n\nLink: https://example.org\n" + diff,
+		},
+		{
+			name: "quoted-printable",
+			msg:  "Subject: [PATCH] Add foo\nContent-Transfer-Encoding: quoted-printable\n\nCaf=C3=A9 =\nlong line\n" + diff,
+			want: "Subject: [PATCH] Add foo\nContent-Transfer-Encoding: 8bit\n\nCafé long line\n\nLink: https://example.org\n" + diff,
+		},
+		{
+			name: "not a patch",
+			msg:  "Subject: [PATCH 0/1] Cover letter\n\nHello\n",
+			want: "Subject: [PATCH 0/1] Cover letter\n\nHello\n",
+		},
+	}
+
+	for _, test := range tests {
+		got, err := addPatchTrailer(test.msg, "Link: https://example.org")
+		if err != nil {
+			t.Errorf("addPatchTrailer(%s): %v", test.name, err)
+		} else if got != test.want {
+			t.Errorf("addPatchTrailer(%s): expected %q, got %q", test.name, test.want, got)
+		}
+	}
+}
+
+func TestParseMessageID(t *testing.T) {
+	tests := []struct {
+		s         string
+		messageID string
+		instance  string
+	}{
+		{"1@example.org", "<1@example.org>", ""},
+		{"<1@example.org>", "<1@example.org>", ""},
+		{"<a/b@example.org>", "<a/b@example.org>", ""},
+		{"https://lists.sr.ht/~alice/foo/%3C1@example.org%3E", "<1@example.org>", "lists.sr.ht"},
+		{"~alice/foo/<1@example.org>", "<1@example.org>", ""},
+	}
+
+	for _, test := range tests {
+		messageID, instance, err := parseMessageID(test.s)
+		if err != nil {
+			t.Errorf("parseMessageID(%q): %v", test.s, err)
+		} else if messageID != test.messageID || instance != test.instance {
+			t.Errorf("parseMessageID(%q): expected (%q, %q), got (%q, %q)", test.s, test.messageID, test.instance, messageID, instance)
+		}
+	}
+}
+
+func TestHasNewerPatchsetVersion(t *testing.T) {
+	alice := &listssrht.Entity{CanonicalName: "~alice"}
+	bob := &listssrht.Entity{CanonicalName: "~bob"}
+	patchsets := []listssrht.Patchset{
+		{Id: 1, Subject: "Add foo", Version: 1, Submitter: alice},
+		{Id: 2, Subject: "add FOO", Version: 2, Submitter: alice},
+		{Id: 3, Subject: "Add bar", Version: 1, Submitter: alice},
+		{Id: 4, Subject: "Add bar", Version: 2, Submitter: bob},
+	}
+
+	tests := []struct {
+		id   int32
+		want bool
+	}{
+		{1, true},
+		{2, false},
+		{3, false}, // newer version from another submitter
+		{4, false},
+	}
+
+	for _, test := range tests {
+		got := hasNewerPatchsetVersion(patchsets, &patchsets[test.id-1])
+		if got != test.want {
+			t.Errorf("hasNewerPatchsetVersion(#%d): expected %v, got %v", test.id, test.want, got)
+		}
+	}
+}
+
+func TestParseGitCommits(t *testing.T) {
+	out := "aaaa\x00alice@example.org\x00Add foo\n" +
+		"bbbb\x00bob@example.org\x00Fix bar: handle\x00NUL\n" +
+		"\n"
+	patchIDs := [][2]string{
+		{"1111", "aaaa"},
+		{"2222", "cccc"},
+	}
+
+	want := []gitCommit{
+		{Hash: "aaaa", Author: "alice@example.org", Subject: "Add foo", PatchID: "1111"},
+		{Hash: "bbbb", Author: "bob@example.org", Subject: "Fix bar: handle\x00NUL"},
+	}
+	got := parseGitCommits(out, patchIDs)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGitCommits: expected %q, got %q", want, got)
+	}
+}
+
+func TestMatchAppliedPatch(t *testing.T) {
+	commits := []gitCommit{
+		{Hash: "aaaa", Author: "alice@example.org", Subject: "Add foo", PatchID: "1111"},
+		{Hash: "bbbb", Author: "alice@example.org", Subject: "Add bar", PatchID: "2222"},
+	}
+
+	tests := []struct {
+		name    string
+		patch   mboxPatch
+		patchID string
+		want    patchMatch
+	}{
+		{"patch ID", mboxPatch{Subject: "Add foo v2", Author: "bob@example.org"}, "1111", patchApplied},
+		{"edited", mboxPatch{Subject: "add BAR", Author: "Alice@example.org"}, "3333", patchLikelyApplied},
+		{"no patch ID", mboxPatch{Subject: "Add bar", Author: "alice@example.org"}, "", patchLikelyApplied},
+		{"other author", mboxPatch{Subject: "Add bar", Author: "bob@example.org"}, "3333", patchNotApplied},
+		{"not applied", mboxPatch{Subject: "Add baz", Author: "alice@example.org"}, "4444", patchNotApplied},
+	}
+
+	for _, test := range tests {
+		got := matchAppliedPatch(commits, &test.patch, test.patchID)
+		if got != test.want {
+			t.Errorf("matchAppliedPatch(%s): expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"git.sr.ht/~xenrox/hut/srht/metasrht"
+)
+
+// outgoingMail is a plain-text email composed by hut.
+type outgoingMail struct {
+	From       *mail.Address
+	To, Cc     []*mail.Address
+	Subject    string
+	Date       time.Time
+	MessageID  string // with angle brackets
+	InReplyTo  string // with angle brackets
+	References []string
+	Body       string
+}
+
+// Bytes formats the email with LF line endings.
+func (m *outgoingMail) Bytes() []byte {
+	var sb strings.Builder
+	writeHeader := func(k, v string) {
+		fmt.Fprintf(&sb, "%s: %s\n", k, v)
+	}
+
+	writeHeader("From", m.From.String())
+	if len(m.To) > 0 {
+		writeHeader("To", formatAddressList(m.To))
+	}
+	if len(m.Cc) > 0 {
+		writeHeader("Cc", formatAddressList(m.Cc))
+	}
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader("Date", m.Date.Format(time.RFC1123Z))
+	writeHeader("Message-ID", m.MessageID)
+	if m.InReplyTo != "" {
+		writeHeader("In-Reply-To", m.InReplyTo)
+	}
+	if len(m.References) > 0 {
+		writeHeader("References", strings.Join(m.References, "\n "))
+	}
+	writeHeader("MIME-Version", "1.0")
+	writeHeader("Content-Type", "text/plain; charset=utf-8")
+	writeHeader("Content-Transfer-Encoding", "8bit")
+	sb.WriteString("\n")
+
+	body := strings.ReplaceAll(m.Body, "\r\n", "\n")
+	sb.WriteString(strings.TrimRight(body, "\n") + "\n")
+	return []byte(sb.String())
+}
+
+// Recipients returns the addresses of all recipients, without duplicates.
+func (m *outgoingMail) Recipients() []string {
+	var rcpts []string
+	seen := make(map[string]bool)
+	for _, addr := range append(append([]*mail.Address(nil), m.To...), m.Cc...) {
+		k := strings.ToLower(addr.Address)
+		if !seen[k] {
+			seen[k] = true
+			rcpts = append(rcpts, addr.Address)
+		}
+	}
+	return rcpts
+}
+
+func formatAddressList(addrs []*mail.Address) string {
+	l := make([]string, len(addrs))
+	for i, addr := range addrs {
+		l[i] = addr.String()
+	}
+	return strings.Join(l, ",\n ")
+}
+
+// generateMessageID returns a new Message-ID, with angle brackets.
+func generateMessageID(from *mail.Address) string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	domain := "localhost"
+	if i := strings.LastIndex(from.Address, "@"); i >= 0 {
+		domain = from.Address[i+1:]
+	}
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().Unix(), hex.EncodeToString(b), domain)
+}
+
+// getMailFrom returns the sender address from the mail configuration, or the
+// email address of the meta.sr.ht account as a fallback.
+func getMailFrom(ctx context.Context, cmd *cobra.Command, cfg *MailConfig) (*mail.Address, error) {
+	if cfg != nil && cfg.From != "" {
+		from, err := mail.ParseAddress(cfg.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from address: %v", err)
+		}
+		return from, nil
+	}
+
+	c := createClient("meta", cmd)
+	me, err := metasrht.FetchMe(c.Client, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch email address: %v", err)
+	}
+	return &mail.Address{Address: me.Email}, nil
+}
+
+// deliverMail writes the email to a file if output is set, and sends it with
+// the configured transport otherwise.
+func deliverMail(cfg *MailConfig, m *outgoingMail, output string) error {
+	return deliverMessage(cfg, m.From.Address, m.Recipients(), m.Date, m.Bytes(), output)
+}
+
+// deliverMessage is like deliverMail, for an already formatted message. Files
+// ending in ".mbox" are appended to, others are overwritten. "-" designates
+// stdout.
+func deliverMessage(cfg *MailConfig, from string, rcpts []string, date time.Time, msg []byte, output string) error {
+	if output != "" {
+		return writeMailFile(output, from, date, msg)
+	}
+
+	if cfg == nil || (cfg.SMTP == "" && cfg.SendmailCmd == nil) {
+		return errors.New("no mail transport configured (use --output to save the email instead)")
+	}
+
+	if cfg.SendmailCmd != nil {
+		args := append(append([]string(nil), cfg.SendmailCmd[1:]...), rcpts...)
+		cmd := exec.Command(cfg.SendmailCmd[0], args...)
+		cmd.Stdin = bytes.NewReader(msg)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("sendmail-cmd failed: %v", err)
+		}
+		return nil
+	}
+
+	return sendMailSMTP(cfg, from, rcpts, msg)
+}
+
+func writeMailFile(filename, from string, date time.Time, msg []byte) error {
+	var w io.Writer
+	if filename == "-" {
+		w = os.Stdout
+	} else {
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if strings.HasSuffix(filename, ".mbox") {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
+		f, err := os.OpenFile(filename, flags, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if strings.HasSuffix(filename, ".mbox") {
+		return writeMboxMessage(w, from, date, msg)
+	}
+
+	_, err := w.Write(msg)
+	return err
+}
+
+// writeMboxMessage appends a message to an mbox, using the mboxrd format.
+func writeMboxMessage(w io.Writer, from string, date time.Time, msg []byte) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From %s %s\n", from, date.UTC().Format(time.ANSIC))
+	for _, line := range strings.SplitAfter(string(msg), "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			sb.WriteString(">")
+		}
+		sb.WriteString(line)
+	}
+	sb.WriteString("\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// readMboxMessages splits an mbox in the mboxrd format into messages, without
+// their "From " separator line.
+func readMboxMessages(mbox string) []string {
+	var messages []string
+	scanMboxMessages(strings.NewReader(mbox), func(msg string) error {
+		messages = append(messages, msg)
+		return nil
+	})
+	return messages
+}
+
+// scanMboxMessages is like readMboxMessages, but reads the mbox line by line
+// and calls fn as soon as a message is complete.
+func scanMboxMessages(r io.Reader, fn func(msg string) error) error {
+	br := bufio.NewReader(r)
+	var cur strings.Builder
+	var started bool
+	flush := func() error {
+		msg := cur.String()
+		cur.Reset()
+		if !started {
+			return nil
+		}
+		// Drop the blank line separating messages
+		if strings.HasSuffix(msg, "\n\n") {
+			msg = msg[:len(msg)-1]
+		}
+		return fn(msg)
+	}
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if strings.HasSuffix(line, "\r\n") {
+			line = line[:len(line)-2] + "\n"
+		}
+
+		if strings.HasPrefix(line, "From ") {
+			if err := flush(); err != nil {
+				return err
+			}
+			started = true
+		} else {
+			if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+				line = line[1:]
+			}
+			cur.WriteString(line)
+		}
+
+		if err == io.EOF {
+			return flush()
+		}
+	}
+}
+
+// decodeTransferEncoding decodes a message body according to its
+// Content-Transfer-Encoding header.
+func decodeTransferEncoding(encoding string, body []byte) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return io.ReadAll(quotedprintable.NewReader(bytes.NewReader(body)))
+	case "base64":
+		return io.ReadAll(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(body)))
+	default:
+		return body, nil
+	}
+}
+
+// sendMailSMTP sends an email with the SMTP server from the configuration.
+// "smtps://" URLs use implicit TLS, "smtp://" URLs use STARTTLS.
+func sendMailSMTP(cfg *MailConfig, from string, rcpts []string, msg []byte) error {
+	u, err := url.Parse(cfg.SMTP)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP URL: %v", err)
+	}
+
+	host := u.Hostname()
+	port := u.Port()
+	tlsConfig := &tls.Config{ServerName: host}
+
+	var conn net.Conn
+	switch u.Scheme {
+	case "smtps":
+		if port == "" {
+			port = "465"
+		}
+		conn, err = tls.Dial("tcp", net.JoinHostPort(host, port), tlsConfig)
+	case "smtp":
+		if port == "" {
+			port = "587"
+		}
+		conn, err = net.Dial("tcp", net.JoinHostPort(host, port))
+	default:
+		return fmt.Errorf("unsupported SMTP URL scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %v", err)
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to connect to SMTP server: %v", err)
+	}
+	defer c.Close()
+
+	if u.Scheme == "smtp" {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP server doesn't support STARTTLS")
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS failed: %v", err)
+		}
+	}
+
+	if u.User != nil {
+		password, ok := u.User.Password()
+		if !ok && cfg.SMTPPasswordCmd != nil {
+			password, err = getSMTPPassword(cfg.SMTPPasswordCmd)
+			if err != nil {
+				return err
+			}
+		}
+		if err := c.Auth(smtp.PlainAuth("", u.User.Username(), password, host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %v", err)
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range rcpts {
+		if err := c.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("recipient %q rejected: %v", rcpt, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+func getSMTPPassword(command []string) (string, error) {
+	output, err := exec.Command(command[0], command[1:]...).Output()
+	if err != nil {
+		return "", fmt.Errorf("could not execute smtp-password-cmd: %v", err)
+	}
+
+	password, _, _ := strings.Cut(string(output), "\n")
+	if password == "" {
+		return "", errors.New("smtp-password-cmd did not return a password")
+	}
+	return password, nil
+}
+
+// localMailbox is a local mail store messages can be delivered to.
+type localMailbox interface {
+	Deliver(msg []byte) error
+	Close() error
+}
+
+// openLocalMailbox opens the Maildir or mbox at path. Existing directories
+// and paths with a trailing slash are Maildirs, anything else is an mbox.
+func openLocalMailbox(path string) (localMailbox, error) {
+	fi, err := os.Stat(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if (fi != nil && fi.IsDir()) || (fi == nil && strings.HasSuffix(path, string(os.PathSeparator))) {
+		for _, sub := range []string{"tmp", "new", "cur"} {
+			if err := os.MkdirAll(filepath.Join(path, sub), 0o700); err != nil {
+				return nil, err
+			}
+		}
+		return &maildirMailbox{dir: path}, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &mboxMailbox{f: f}, nil
+}
+
+type maildirMailbox struct {
+	dir      string
+	hostname string
+	n        int
+}
+
+func (m *maildirMailbox) Deliver(msg []byte) error {
+	if m.hostname == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "localhost"
+		}
+		hostname = strings.ReplaceAll(hostname, "/", `\057`)
+		m.hostname = strings.ReplaceAll(hostname, ":", `\072`)
+	}
+
+	m.n++
+	now := time.Now()
+	name := fmt.Sprintf("%d.M%dP%dQ%d.%s", now.Unix(), now.Nanosecond()/1000, os.Getpid(), m.n, m.hostname)
+
+	// Messages are written to tmp first, so that readers never see partial
+	// messages in new
+	tmp := filepath.Join(m.dir, "tmp", name)
+	if err := os.WriteFile(tmp, normalizeNewlines(msg), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(m.dir, "new", name))
+}
+
+func (m *maildirMailbox) Close() error {
+	return nil
+}
+
+type mboxMailbox struct {
+	f *os.File
+}
+
+func (m *mboxMailbox) Deliver(msg []byte) error {
+	msg = normalizeNewlines(msg)
+
+	from := "MAILER-DAEMON"
+	date := time.Now()
+	if hdr, err := mail.ReadMessage(bytes.NewReader(msg)); err == nil {
+		if addr, err := mail.ParseAddress(hdr.Header.Get("From")); err == nil {
+			from = addr.Address
+		}
+		if t, err := hdr.Header.Date(); err == nil {
+			date = t
+		}
+	}
+
+	return writeMboxMessage(m.f, from, date, msg)
+}
+
+func (m *mboxMailbox) Close() error {
+	return m.f.Close()
+}
+
+func normalizeNewlines(msg []byte) []byte {
+	return bytes.ReplaceAll(msg, []byte("\r\n"), []byte("\n"))
+}
+
+// messageID returns the Message-ID of a message, without angle brackets.
+func messageID(msg []byte) string {
+	hdr, err := mail.ReadMessage(bytes.NewReader(msg))
+	if err != nil {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(hdr.Header.Get("Message-ID")), "<>")
+}
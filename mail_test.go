@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadMboxMessages(t *testing.T) {
+	tests := []struct {
+		mbox string
+		want []string
+	}{
+		{"", nil},
+		{"garbage\n", nil},
+		{"From a\nSubject: 1\n\nHi\n", []string{"Subject: 1\n\nHi\n"}},
+		{
+			"From a\r\nSubject: 1\r\n\r\nHi\r\n\r\nFrom b\r\nSubject: 2\r\n\r\nBye\r\n",
+			[]string{"Subject: 1\n\nHi\n", "Subject: 2\n\nBye\n"},
+		},
+		{
+			"From a\nSubject: 1\n\n>From here\n>>From there\n> From quoted\n",
+			[]string{"Subject: 1\n\nFrom here\n>From there\n> From quoted\n"},
+		},
+		{"From a\nSubject: 1\n\nno newline", []string{"Subject: 1\n\nno newline"}},
+	}
+
+	for _, test := range tests {
+		got := readMboxMessages(test.mbox)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("readMboxMessages(%q): expected %q, got %q", test.mbox, test.want, got)
+		}
+	}
+}
+
+func TestScanMboxMessagesError(t *testing.T) {
+	errStop := errors.New("stop")
+	n := 0
+	err := scanMboxMessages(strings.NewReader("From a\n1\n\nFrom b\n2\n\nFrom c\n3\n"), func(msg string) error {
+		n++
+		if n == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("scanMboxMessages: expected %v, got %v", errStop, err)
+	}
+	if n != 2 {
+		t.Errorf("scanMboxMessages: expected 2 messages, got %d", n)
+	}
+}
+
+func TestWriteMboxMessage(t *testing.T) {
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600))
+
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{
+			"Subject: 1\n\nHi\n",
+			"From alice@example.org Tue Jan  2 02:04:05 2024\nSubject: 1\n\nHi\n\n",
+		},
+		{
+			"Subject: 1\n\nFrom here\n>From there\n> From quoted\n",
+			"From alice@example.org Tue Jan  2 02:04:05 2024\nSubject: 1\n\n>From here\n>>From there\n> From quoted\n\n",
+		},
+	}
+
+	for _, test := range tests {
+		var sb strings.Builder
+		if err := writeMboxMessage(&sb, "alice@example.org", date, []byte(test.msg)); err != nil {
+			t.Fatal(err)
+		}
+		if sb.String() != test.want {
+			t.Errorf("writeMboxMessage(%q): expected %q, got %q", test.msg, test.want, sb.String())
+		}
+
+		got := readMboxMessages(sb.String())
+		if want := []string{test.msg}; !reflect.DeepEqual(got, want) {
+			t.Errorf("readMboxMessages(writeMboxMessage(%q)): expected %q, got %q", test.msg, want, got)
+		}
+	}
+}
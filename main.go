@@ -66,6 +66,7 @@ func main() {
 	cmd.AddCommand(newPagesCommand())
 	cmd.AddCommand(newPasteCommand())
 	cmd.AddCommand(newTodoCommand())
+	cmd.AddCommand(newWebhookCommand())
 
 	if err := cmd.ExecuteContext(ctx); err != nil {
 		os.Exit(1)
@@ -141,6 +142,15 @@ func parseResourceName(name string) (resource, owner, instance string) {
 	return resource, owner, instance
 }
 
+// formatResourceName is the reverse of parseResourceName, without the
+// instance.
+func formatResourceName(name, owner string) string {
+	if owner == "" {
+		return name
+	}
+	return owner + "/" + name
+}
+
 func parseInt32(s string) (int32, error) {
 	i, err := strconv.ParseInt(s, 10, 32)
 	return int32(i), err
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -609,16 +610,16 @@ func newMetaUserWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newMetaUserWebhookCreateCommand())
 	cmd.AddCommand(newMetaUserWebhookListCommand())
 	cmd.AddCommand(newMetaUserWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadMetaUserWebhookDeliveries, loadMetaUserWebhookSample, nil)
 	return cmd
 }
 
 func newMetaUserWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		c := createClient("meta", cmd)
 
 		var config metasrht.ProfileWebhookInput
 		config.Url = url
@@ -629,6 +630,19 @@ func newMetaUserWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("meta", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("meta", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClient("meta", cmd)
 
 		webhook, err := metasrht.CreateUserWebhook(c.Client, ctx, config)
 		if err != nil {
@@ -654,7 +668,10 @@ func newMetaUserWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -726,6 +743,50 @@ func newMetaUserWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadMetaUserWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	c := createClient("meta", cmd)
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		webhook, err := metasrht.ProfileWebhookDeliveries(c.Client, ctx, id, (*metasrht.Cursor)(cursor))
+		if err != nil {
+			return nil, nil, err
+		} else if webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertMetaWebhookDeliveries(webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadMetaUserWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	c := createClient("meta", cmd)
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		webhook, err := metasrht.ProfileWebhookSample(c.Client, ctx, id, metasrht.WebhookEvent(event))
+		if err != nil {
+			return "", err
+		} else if webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return webhook.Sample, nil
+	}
+}
+
+func convertMetaWebhookDeliveries(cursor *metasrht.WebhookDeliveryCursor) ([]webhookDelivery, *string) {
+	var deliveries []webhookDelivery
+	for _, delivery := range cursor.Results {
+		deliveries = append(deliveries, webhookDelivery{
+			UUID:            delivery.Uuid,
+			Date:            delivery.Date.Time,
+			Event:           string(delivery.Event),
+			RequestBody:     delivery.RequestBody,
+			ResponseBody:    delivery.ResponseBody,
+			ResponseHeaders: delivery.ResponseHeaders,
+			ResponseStatus:  delivery.ResponseStatus,
+		})
+	}
+	return deliveries, (*string)(cursor.Cursor)
+}
+
 func newMetaOAuthCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "oauth",
@@ -3,6 +3,7 @@ package main
 import (
 	"archive/tar"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -282,16 +283,16 @@ func newPagesUserWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newPagesUserWebhookCreateCommand())
 	cmd.AddCommand(newPagesUserWebhookListCommand())
 	cmd.AddCommand(newPagesUserWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadPagesUserWebhookDeliveries, loadPagesUserWebhookSample, nil)
 	return cmd
 }
 
 func newPagesUserWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		c := createClient("pages", cmd)
 
 		var config pagessrht.UserWebhookInput
 		config.Url = url
@@ -302,6 +303,19 @@ func newPagesUserWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("pages", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("pages", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClient("pages", cmd)
 
 		webhook, err := pagessrht.CreateUserWebhook(c.Client, ctx, config)
 		if err != nil {
@@ -327,7 +341,10 @@ func newPagesUserWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -399,6 +416,50 @@ func newPagesUserWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadPagesUserWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	c := createClient("pages", cmd)
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		webhook, err := pagessrht.UserWebhookDeliveries(c.Client, ctx, id, (*pagessrht.Cursor)(cursor))
+		if err != nil {
+			return nil, nil, err
+		} else if webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertPagesWebhookDeliveries(webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadPagesUserWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	c := createClient("pages", cmd)
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		webhook, err := pagessrht.UserWebhookSample(c.Client, ctx, id, pagessrht.WebhookEvent(event))
+		if err != nil {
+			return "", err
+		} else if webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return webhook.Sample, nil
+	}
+}
+
+func convertPagesWebhookDeliveries(cursor *pagessrht.WebhookDeliveryCursor) ([]webhookDelivery, *string) {
+	var deliveries []webhookDelivery
+	for _, delivery := range cursor.Results {
+		deliveries = append(deliveries, webhookDelivery{
+			UUID:            delivery.Uuid,
+			Date:            delivery.Date.Time,
+			Event:           string(delivery.Event),
+			RequestBody:     delivery.RequestBody,
+			ResponseBody:    delivery.ResponseBody,
+			ResponseHeaders: delivery.ResponseHeaders,
+			ResponseStatus:  delivery.ResponseStatus,
+		})
+	}
+	return deliveries, (*string)(cursor.Cursor)
+}
+
 func newPagesACLCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "acl",
@@ -407,6 +468,7 @@ func newPagesACLCommand() *cobra.Command {
 	cmd.AddCommand(newPagesACLUpdateCommand())
 	cmd.AddCommand(newPagesACLDeleteCommand())
 	cmd.AddCommand(newPagesACLListCommand())
+	cmd.AddCommand(newPagesACLApplyCommand())
 	return cmd
 }
 
@@ -477,6 +539,110 @@ func newPagesACLDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func newPagesACLApplyCommand() *cobra.Command {
+	var filename, domain, protocol string
+	var prune, dryRun bool
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		c := createClient("pages", cmd)
+
+		pagesProtocol, err := pagessrht.ParseProtocol(protocol)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		resources, err := readACLFile(filename, "site", aclSetPermissions("publish"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, resource := range resources {
+			name := resource.Name
+			if name == "" {
+				name = domain
+			}
+			if name == "" {
+				log.Fatal("top-level ACL entries require --domain")
+			}
+
+			backend := newPagesACLBackend(c, name, pagesProtocol)
+			if err := applyACL(ctx, name, backend, resource.Entries, prune, dryRun); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "apply",
+		Short:             "Apply ACL entries from a file",
+		Args:              cobra.ExactArgs(0),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().StringVarP(&filename, "file", "f", "", "ACL file")
+	cmd.MarkFlagRequired("file")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete entries not listed in the file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the changes")
+	cmd.Flags().StringVarP(&domain, "domain", "d", "", "domain name")
+	cmd.RegisterFlagCompletionFunc("domain", completeDomain)
+	cmd.Flags().StringVarP(&protocol, "protocol", "p", "HTTPS",
+		"protocol (HTTPS or GEMINI)")
+	cmd.RegisterFlagCompletionFunc("protocol", completeProtocol)
+	return cmd
+}
+
+func newPagesACLBackend(c *Client, domain string, protocol pagessrht.Protocol) *aclBackend {
+	var siteID int32
+	return &aclBackend{
+		List: func(ctx context.Context) ([]aclEntry, error) {
+			var (
+				entries []aclEntry
+				cursor  *pagessrht.Cursor
+			)
+			for {
+				site, err := pagessrht.Acls(c.Client, ctx, domain, protocol, cursor)
+				if err != nil {
+					return nil, err
+				} else if site == nil {
+					return nil, fmt.Errorf("no such site %q", domain)
+				}
+				siteID = site.Id
+
+				for _, acl := range site.Acls.Results {
+					var permissions []string
+					if acl.Publish {
+						permissions = append(permissions, "publish")
+					}
+					entries = append(entries, aclEntry{ID: acl.Id, Entity: acl.Entity.CanonicalName, Permissions: permissions})
+				}
+
+				cursor = site.Acls.Cursor
+				if cursor == nil {
+					return entries, nil
+				}
+			}
+		},
+		Update: func(ctx context.Context, entity string, permissions []string) error {
+			user, err := pagessrht.UserID(c.Client, ctx, strings.TrimLeft(entity, ownerPrefixes))
+			if err != nil {
+				return err
+			} else if user == nil {
+				return fmt.Errorf("no such user %q", entity)
+			}
+
+			input := pagessrht.ACLInput{
+				Publish: sliceContains(permissions, "publish"),
+			}
+			_, err = pagessrht.UpdateSiteACL(c.Client, ctx, siteID, user.Id, input)
+			return err
+		},
+		Delete: func(ctx context.Context, id int32) error {
+			_, err := pagessrht.DeleteSiteACL(c.Client, ctx, id)
+			return err
+		},
+	}
+}
+
 func newPagesACLListCommand() *cobra.Command {
 	var count int
 	var domain, protocol string
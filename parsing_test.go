@@ -54,3 +54,23 @@ func TestParseBuildID(t *testing.T) {
 		}
 	}
 }
+
+func TestParseBuildSource(t *testing.T) {
+	tests := []struct {
+		s      string
+		source buildSource
+	}{
+		{"https://git.sr.ht/~xenrox/hut", buildSource{"git", "https://git.sr.ht/~xenrox/hut", "", "hut"}},
+		{"https://git.sr.ht/~xenrox/hut#v0.7.0", buildSource{"git", "https://git.sr.ht/~xenrox/hut", "v0.7.0", "hut"}},
+		{"hg+https://hg.sr.ht/~user/repo", buildSource{"hg", "https://hg.sr.ht/~user/repo", "", "repo"}},
+		{"git@git.sr.ht:~user/repo.git#main", buildSource{"git", "git@git.sr.ht:~user/repo.git", "main", "repo"}},
+		{"https://github.com/user/repo.git/", buildSource{"git", "https://github.com/user/repo.git/", "", "repo"}},
+	}
+
+	for _, test := range tests {
+		source := parseBuildSource(test.s)
+		if source != test.source {
+			t.Errorf("parseBuildSource(%q): expected %+v, got %+v", test.s, test.source, source)
+		}
+	}
+}
@@ -282,16 +282,16 @@ func newPasteUserWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newPasteUserWebhookCreateCommand())
 	cmd.AddCommand(newPasteUserWebhookListCommand())
 	cmd.AddCommand(newPasteUserWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadPasteUserWebhookDeliveries, loadPasteUserWebhookSample, nil)
 	return cmd
 }
 
 func newPasteUserWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		c := createClient("paste", cmd)
 
 		var config pastesrht.UserWebhookInput
 		config.Url = url
@@ -302,6 +302,19 @@ func newPasteUserWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("paste", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("paste", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClient("paste", cmd)
 
 		webhook, err := pastesrht.CreateUserWebhook(c.Client, ctx, config)
 		if err != nil {
@@ -327,7 +340,10 @@ func newPasteUserWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -399,6 +415,50 @@ func newPasteUserWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadPasteUserWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	c := createClient("paste", cmd)
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		webhook, err := pastesrht.UserWebhookDeliveries(c.Client, ctx, id, (*pastesrht.Cursor)(cursor))
+		if err != nil {
+			return nil, nil, err
+		} else if webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertPasteWebhookDeliveries(webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadPasteUserWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	c := createClient("paste", cmd)
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		webhook, err := pastesrht.UserWebhookSample(c.Client, ctx, id, pastesrht.WebhookEvent(event))
+		if err != nil {
+			return "", err
+		} else if webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return webhook.Sample, nil
+	}
+}
+
+func convertPasteWebhookDeliveries(cursor *pastesrht.WebhookDeliveryCursor) ([]webhookDelivery, *string) {
+	var deliveries []webhookDelivery
+	for _, delivery := range cursor.Results {
+		deliveries = append(deliveries, webhookDelivery{
+			UUID:            delivery.Uuid,
+			Date:            delivery.Date.Time,
+			Event:           string(delivery.Event),
+			RequestBody:     delivery.RequestBody,
+			ResponseBody:    delivery.ResponseBody,
+			ResponseHeaders: delivery.ResponseHeaders,
+			ResponseStatus:  delivery.ResponseStatus,
+		})
+	}
+	return deliveries, (*string)(cursor.Cursor)
+}
+
 func completePasteID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	ctx := cmd.Context()
 	c := createClient("paste", cmd)
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"git.sr.ht/~xenrox/hut/srht/buildssrht"
+	"git.sr.ht/~xenrox/hut/srht/gitsrht"
+	"git.sr.ht/~xenrox/hut/srht/hgsrht"
+	"git.sr.ht/~xenrox/hut/srht/listssrht"
+	"git.sr.ht/~xenrox/hut/srht/metasrht"
+	"git.sr.ht/~xenrox/hut/srht/pagessrht"
+	"git.sr.ht/~xenrox/hut/srht/pastesrht"
+	"git.sr.ht/~xenrox/hut/srht/todosrht"
+)
+
+var serviceSchemas = map[string]string{
+	"builds": buildssrht.Schema,
+	"git":    gitsrht.Schema,
+	"hg":     hgsrht.Schema,
+	"lists":  listssrht.Schema,
+	"meta":   metasrht.Schema,
+	"pages":  pagessrht.Schema,
+	"paste":  pastesrht.Schema,
+	"todo":   todosrht.Schema,
+}
+
+// webhookPayloadTypes maps webhook events to the type implementing the
+// WebhookPayload interface sent for them.
+var webhookPayloadTypes = map[string]string{
+	"JOB_CREATED":       "JobEvent",
+	"JOB_UPDATED":       "JobEvent",
+	"REPO_CREATED":      "RepositoryEvent",
+	"REPO_UPDATE":       "RepositoryEvent",
+	"REPO_DELETED":      "RepositoryEvent",
+	"GIT_PRE_RECEIVE":   "GitEvent",
+	"GIT_POST_RECEIVE":  "GitEvent",
+	"LIST_CREATED":      "MailingListEvent",
+	"LIST_UPDATED":      "MailingListEvent",
+	"LIST_DELETED":      "MailingListEvent",
+	"EMAIL_RECEIVED":    "EmailEvent",
+	"PATCHSET_RECEIVED": "PatchsetEvent",
+	"PROFILE_UPDATE":    "ProfileUpdateEvent",
+	"PGP_KEY_ADDED":     "PGPKeyEvent",
+	"PGP_KEY_REMOVED":   "PGPKeyEvent",
+	"SSH_KEY_ADDED":     "SSHKeyEvent",
+	"SSH_KEY_REMOVED":   "SSHKeyEvent",
+	"SITE_PUBLISHED":    "SiteEvent",
+	"SITE_UNPUBLISHED":  "SiteEvent",
+	"PASTE_CREATED":     "PasteEvent",
+	"PASTE_UPDATED":     "PasteEvent",
+	"PASTE_DELETED":     "PasteEvent",
+	"TRACKER_CREATED":   "TrackerEvent",
+	"TRACKER_UPDATE":    "TrackerEvent",
+	"TRACKER_DELETED":   "TrackerEvent",
+	"TICKET_CREATED":    "TicketEvent",
+	"TICKET_UPDATE":     "TicketEvent",
+	"TICKET_DELETED":    "TicketDeletedEvent",
+	"LABEL_CREATED":     "LabelEvent",
+	"LABEL_UPDATE":      "LabelEvent",
+	"LABEL_DELETED":     "LabelEvent",
+	"EVENT_CREATED":     "EventCreated",
+}
+
+func loadServiceSchema(service string) (*ast.Schema, error) {
+	src, ok := serviceSchemas[service]
+	if !ok {
+		return nil, fmt.Errorf("no schema for service %q", service)
+	}
+
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: service, Input: src})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %v.sr.ht schema: %v", service, err)
+	}
+	return schema, nil
+}
+
+// parseQuery parses a query and validates it against the schema of a
+// service.
+func parseQuery(service, query string) (*ast.Schema, *ast.QueryDocument, error) {
+	schema, err := loadServiceSchema(service)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, errs := gqlparser.LoadQuery(schema, query)
+	if len(errs) > 0 {
+		return nil, nil, formatQueryErrors(schema, errs)
+	}
+	return schema, doc, nil
+}
+
+var unknownFieldTypeRegexp = regexp.MustCompile(`on type "(\w+)"`)
+
+func formatQueryErrors(schema *ast.Schema, errs gqlerror.List) error {
+	var sb strings.Builder
+	sb.WriteString("invalid query:")
+	for _, err := range errs {
+		sb.WriteString("\n  ")
+		if len(err.Locations) > 0 {
+			fmt.Fprintf(&sb, "%d:%d: ", err.Locations[0].Line, err.Locations[0].Column)
+		}
+		sb.WriteString(err.Message)
+
+		// gqlparser only suggests fields with a similar name, list all
+		// fields if there are none
+		if err.Rule == "FieldsOnCorrectType" && !strings.Contains(err.Message, "Did you mean") {
+			m := unknownFieldTypeRegexp.FindStringSubmatch(err.Message)
+			if m != nil && schema.Types[m[1]] != nil {
+				var fields []string
+				for _, field := range schema.Types[m[1]].Fields {
+					if !strings.HasPrefix(field.Name, "__") {
+						fields = append(fields, field.Name)
+					}
+				}
+				sort.Strings(fields)
+				fmt.Fprintf(&sb, " Available fields: %v.", strings.Join(fields, ", "))
+			}
+		}
+	}
+	return fmt.Errorf("%v", sb.String())
+}
+
+// sampleQueryResponse builds the data a query would return, filled with
+// placeholder values. event selects the webhook event used for webhook
+// payloads.
+func sampleQueryResponse(schema *ast.Schema, doc *ast.QueryDocument, event string) ([]byte, error) {
+	if len(doc.Operations) != 1 {
+		return nil, fmt.Errorf("expected exactly one operation, got %d", len(doc.Operations))
+	}
+
+	op := doc.Operations[0]
+	var root *ast.Definition
+	switch op.Operation {
+	case ast.Query:
+		root = schema.Query
+	case ast.Mutation:
+		root = schema.Mutation
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", op.Operation)
+	}
+
+	s := &querySampler{schema: schema, event: event}
+	data := s.object(root, op.SelectionSet)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(map[string]any{"data": data}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type querySampler struct {
+	schema *ast.Schema
+	event  string
+}
+
+// sampleObject is a JSON object which preserves the order of its fields.
+type sampleObject []sampleField
+
+type sampleField struct {
+	Key   string
+	Value any
+}
+
+func (obj sampleObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range obj {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (s *querySampler) object(def *ast.Definition, set ast.SelectionSet) sampleObject {
+	obj := sampleObject{}
+	seen := make(map[string]bool)
+	s.collect(def, set, &obj, seen)
+	return obj
+}
+
+func (s *querySampler) collect(def *ast.Definition, set ast.SelectionSet, obj *sampleObject, seen map[string]bool) {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if seen[sel.Alias] {
+				continue
+			}
+			seen[sel.Alias] = true
+
+			var value any
+			if sel.Name == "__typename" {
+				value = def.Name
+			} else {
+				value = s.value(sel.Definition.Type, sel.SelectionSet)
+			}
+			*obj = append(*obj, sampleField{Key: sel.Alias, Value: value})
+		case *ast.InlineFragment:
+			if s.matches(def, sel.TypeCondition) {
+				s.collect(def, sel.SelectionSet, obj, seen)
+			}
+		case *ast.FragmentSpread:
+			if s.matches(def, sel.Definition.TypeCondition) {
+				s.collect(def, sel.Definition.SelectionSet, obj, seen)
+			}
+		}
+	}
+}
+
+func (s *querySampler) matches(def *ast.Definition, typeCondition string) bool {
+	if typeCondition == "" || typeCondition == def.Name {
+		return true
+	}
+	cond := s.schema.Types[typeCondition]
+	if cond == nil || !cond.IsAbstractType() {
+		return false
+	}
+	for _, t := range s.schema.GetPossibleTypes(cond) {
+		if t.Name == def.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *querySampler) value(t *ast.Type, set ast.SelectionSet) any {
+	if t.Elem != nil {
+		return []any{s.value(t.Elem, set)}
+	}
+
+	def := s.schema.Types[t.NamedType]
+	switch def.Kind {
+	case ast.Object:
+		return s.object(def, set)
+	case ast.Interface, ast.Union:
+		return s.object(s.concreteType(def), set)
+	case ast.Enum:
+		if def.Name == "WebhookEvent" && s.event != "" {
+			return s.event
+		}
+		return def.EnumValues[0].Name
+	}
+
+	switch def.Name {
+	case "Int":
+		return 42
+	case "Float":
+		return 4.2
+	case "Boolean":
+		return true
+	case "ID":
+		return "1"
+	case "Time":
+		return "2006-01-02T15:04:05Z"
+	case "Upload":
+		return nil
+	default:
+		return "string"
+	}
+}
+
+// concreteType picks the type used to fill an abstract type.
+func (s *querySampler) concreteType(def *ast.Definition) *ast.Definition {
+	if def.Name == "WebhookPayload" {
+		if name, ok := webhookPayloadTypes[s.event]; ok && s.schema.Types[name] != nil {
+			return s.schema.Types[name]
+		}
+	}
+	return s.schema.GetPossibleTypes(def)[0]
+}
+
+// checkWebhookQuery validates a webhook query against the schema of a
+// service.
+func checkWebhookQuery(service, query string) error {
+	_, _, err := parseQuery(service, query)
+	if err != nil {
+		return fmt.Errorf("%v\n(use --no-validate if the server schema is newer than the bundled one)", err)
+	}
+	return nil
+}
+
+// printWebhookSamples prints the payloads a webhook query would produce for
+// each event.
+func printWebhookSamples(service, query string, events []string) error {
+	schema, doc, err := parseQuery(service, query)
+	if err != nil {
+		return err
+	}
+
+	for i, event := range events {
+		event = strings.ToUpper(event)
+		payload, err := sampleQueryResponse(schema, doc, event)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("# %v\n", event)
+		fmt.Print(string(payload))
+	}
+	return nil
+}
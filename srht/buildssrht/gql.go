@@ -736,3 +736,25 @@ func CompleteSecrets(client *gqlclient.Client, ctx context.Context) (secrets *Se
 	err = client.Execute(ctx, op, &respData)
 	return respData.Secrets, err
 }
+
+func UserWebhookDeliveries(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookDeliveries ($id: Int!, $cursor: Cursor) {\n\tuserWebhook(id: $id) {\n\t\tdeliveries(cursor: $cursor) {\n\t\t\t... deliveries\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
+
+func UserWebhookSample(client *gqlclient.Client, ctx context.Context, id int32, event WebhookEvent) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookSample ($id: Int!, $event: WebhookEvent!) {\n\tuserWebhook(id: $id) {\n\t\tsample(event: $event)\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
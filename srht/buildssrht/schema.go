@@ -0,0 +1,8 @@
+package buildssrht
+
+import _ "embed"
+
+// Schema is the GraphQL schema of builds.sr.ht.
+//
+//go:embed schema.graphqls
+var Schema string
@@ -705,9 +705,34 @@ func RepositoryIDByUser(client *gqlclient.Client, ctx context.Context, username
 	return respData.User, err
 }
 
-func ListArtifacts(client *gqlclient.Client, ctx context.Context, name string) (me *User, err error) {
-	op := gqlclient.NewOperation("query listArtifacts ($name: String!) {\n\tme {\n\t\trepository(name: $name) {\n\t\t\t... artifacts\n\t\t}\n\t}\n}\nfragment artifacts on Repository {\n\treferences {\n\t\tresults {\n\t\t\tname\n\t\t\tartifacts {\n\t\t\t\tresults {\n\t\t\t\t\tid\n\t\t\t\t\tfilename\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\n")
+func ListArtifacts(client *gqlclient.Client, ctx context.Context, name string, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query listArtifacts ($name: String!, $cursor: Cursor) {\n\tme {\n\t\trepository(name: $name) {\n\t\t\t... artifacts\n\t\t}\n\t}\n}\nfragment artifacts on Repository {\n\treferences(cursor: $cursor) {\n\t\tresults {\n\t\t\tname\n\t\t\tartifacts {\n\t\t\t\tresults {\n\t\t\t\t\tid\n\t\t\t\t\tfilename\n\t\t\t\t\tchecksum\n\t\t\t\t\tsize\n\t\t\t\t\turl\n\t\t\t\t}\n\t\t\t\tcursor\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
 	op.Var("name", name)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func ListArtifactsByUser(client *gqlclient.Client, ctx context.Context, username string, name string, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query listArtifactsByUser ($username: String!, $name: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepository(name: $name) {\n\t\t\t... artifacts\n\t\t}\n\t}\n}\nfragment artifacts on Repository {\n\treferences(cursor: $cursor) {\n\t\tresults {\n\t\t\tname\n\t\t\tartifacts {\n\t\t\t\tresults {\n\t\t\t\t\tid\n\t\t\t\t\tfilename\n\t\t\t\t\tchecksum\n\t\t\t\t\tsize\n\t\t\t\t\turl\n\t\t\t\t}\n\t\t\t\tcursor\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func ReferenceArtifacts(client *gqlclient.Client, ctx context.Context, name string, ref string, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query referenceArtifacts ($name: String!, $ref: String!, $cursor: Cursor) {\n\tme {\n\t\trepository(name: $name) {\n\t\t\t... referenceArtifacts\n\t\t}\n\t}\n}\nfragment referenceArtifacts on Repository {\n\treference(name: $ref) {\n\t\tartifacts(cursor: $cursor) {\n\t\t\tresults {\n\t\t\t\tid\n\t\t\t\tfilename\n\t\t\t\tchecksum\n\t\t\t\tsize\n\t\t\t\turl\n\t\t\t}\n\t\t\tcursor\n\t\t}\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("ref", ref)
+	op.Var("cursor", cursor)
 	var respData struct {
 		Me *User
 	}
@@ -715,10 +740,12 @@ func ListArtifacts(client *gqlclient.Client, ctx context.Context, name string) (
 	return respData.Me, err
 }
 
-func ListArtifactsByUser(client *gqlclient.Client, ctx context.Context, username string, name string) (user *User, err error) {
-	op := gqlclient.NewOperation("query listArtifactsByUser ($username: String!, $name: String!) {\n\tuser(username: $username) {\n\t\trepository(name: $name) {\n\t\t\t... artifacts\n\t\t}\n\t}\n}\nfragment artifacts on Repository {\n\treferences {\n\t\tresults {\n\t\t\tname\n\t\t\tartifacts {\n\t\t\t\tresults {\n\t\t\t\t\tid\n\t\t\t\t\tfilename\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\n")
+func ReferenceArtifactsByUser(client *gqlclient.Client, ctx context.Context, username string, name string, ref string, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query referenceArtifactsByUser ($username: String!, $name: String!, $ref: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepository(name: $name) {\n\t\t\t... referenceArtifacts\n\t\t}\n\t}\n}\nfragment referenceArtifacts on Repository {\n\treference(name: $ref) {\n\t\tartifacts(cursor: $cursor) {\n\t\t\tresults {\n\t\t\t\tid\n\t\t\t\tfilename\n\t\t\t\tchecksum\n\t\t\t\tsize\n\t\t\t\turl\n\t\t\t}\n\t\t\tcursor\n\t\t}\n\t}\n}\n")
 	op.Var("username", username)
 	op.Var("name", name)
+	op.Var("ref", ref)
+	op.Var("cursor", cursor)
 	var respData struct {
 		User *User
 	}
@@ -748,7 +775,7 @@ func RepositoryByUser(client *gqlclient.Client, ctx context.Context, username st
 }
 
 func Repositories(client *gqlclient.Client, ctx context.Context, cursor *Cursor) (repositories *RepositoryCursor, err error) {
-	op := gqlclient.NewOperation("query repositories ($cursor: Cursor) {\n\trepositories(cursor: $cursor) {\n\t\t... repos\n\t}\n}\nfragment repos on RepositoryCursor {\n\tresults {\n\t\tname\n\t\tdescription\n\t\tvisibility\n\t\towner {\n\t\t\tcanonicalName\n\t\t}\n\t}\n\tcursor\n}\n")
+	op := gqlclient.NewOperation("query repositories ($cursor: Cursor) {\n\trepositories(cursor: $cursor) {\n\t\t... repos\n\t}\n}\nfragment repos on RepositoryCursor {\n\tresults {\n\t\tid\n\t\tname\n\t\tdescription\n\t\tvisibility\n\t\towner {\n\t\t\tcanonicalName\n\t\t}\n\t}\n\tcursor\n}\n")
 	op.Var("cursor", cursor)
 	var respData struct {
 		Repositories *RepositoryCursor
@@ -758,7 +785,7 @@ func Repositories(client *gqlclient.Client, ctx context.Context, cursor *Cursor)
 }
 
 func RepositoriesByUser(client *gqlclient.Client, ctx context.Context, username string, cursor *Cursor) (user *User, err error) {
-	op := gqlclient.NewOperation("query repositoriesByUser ($username: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepositories(cursor: $cursor) {\n\t\t\t... repos\n\t\t}\n\t}\n}\nfragment repos on RepositoryCursor {\n\tresults {\n\t\tname\n\t\tdescription\n\t\tvisibility\n\t\towner {\n\t\t\tcanonicalName\n\t\t}\n\t}\n\tcursor\n}\n")
+	op := gqlclient.NewOperation("query repositoriesByUser ($username: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepositories(cursor: $cursor) {\n\t\t\t... repos\n\t\t}\n\t}\n}\nfragment repos on RepositoryCursor {\n\tresults {\n\t\tid\n\t\tname\n\t\tdescription\n\t\tvisibility\n\t\towner {\n\t\t\tcanonicalName\n\t\t}\n\t}\n\tcursor\n}\n")
 	op.Var("username", username)
 	op.Var("cursor", cursor)
 	var respData struct {
@@ -1019,3 +1046,224 @@ func DeleteGitWebhook(client *gqlclient.Client, ctx context.Context, id int32) (
 	err = client.Execute(ctx, op, &respData)
 	return respData.DeleteGitWebhook, err
 }
+
+func LogByRepoName(client *gqlclient.Client, ctx context.Context, name string, from *string, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query logByRepoName ($name: String!, $from: String, $cursor: Cursor) {\n\tme {\n\t\trepository(name: $name) {\n\t\t\tlog(cursor: $cursor, from: $from) {\n\t\t\t\t... commits\n\t\t\t}\n\t\t}\n\t}\n}\nfragment commits on CommitCursor {\n\tresults {\n\t\tid\n\t\tshortId\n\t\tauthor {\n\t\t\tname\n\t\t\temail\n\t\t\ttime\n\t\t}\n\t\tmessage\n\t\tparents {\n\t\t\tid\n\t\t\tshortId\n\t\t}\n\t}\n\tcursor\n}\n")
+	op.Var("name", name)
+	op.Var("from", from)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func LogByUser(client *gqlclient.Client, ctx context.Context, username string, name string, from *string, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query logByUser ($username: String!, $name: String!, $from: String, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepository(name: $name) {\n\t\t\tlog(cursor: $cursor, from: $from) {\n\t\t\t\t... commits\n\t\t\t}\n\t\t}\n\t}\n}\nfragment commits on CommitCursor {\n\tresults {\n\t\tid\n\t\tshortId\n\t\tauthor {\n\t\t\tname\n\t\t\temail\n\t\t\ttime\n\t\t}\n\t\tmessage\n\t\tparents {\n\t\t\tid\n\t\t\tshortId\n\t\t}\n\t}\n\tcursor\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("from", from)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func CommitByRepoName(client *gqlclient.Client, ctx context.Context, name string, revspec string) (me *User, err error) {
+	op := gqlclient.NewOperation("query commitByRepoName ($name: String!, $revspec: String!) {\n\tme {\n\t\trepository(name: $name) {\n\t\t\towner {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t\trevparse_single(revspec: $revspec) {\n\t\t\t\t... commit\n\t\t\t}\n\t\t}\n\t}\n}\nfragment commit on Commit {\n\tid\n\tshortId\n\tauthor {\n\t\tname\n\t\temail\n\t\ttime\n\t}\n\tcommitter {\n\t\tname\n\t\temail\n\t\ttime\n\t}\n\tmessage\n\tparents {\n\t\tshortId\n\t}\n\tdiff\n}\n")
+	op.Var("name", name)
+	op.Var("revspec", revspec)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func CommitByUser(client *gqlclient.Client, ctx context.Context, username string, name string, revspec string) (user *User, err error) {
+	op := gqlclient.NewOperation("query commitByUser ($username: String!, $name: String!, $revspec: String!) {\n\tuser(username: $username) {\n\t\trepository(name: $name) {\n\t\t\towner {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t\trevparse_single(revspec: $revspec) {\n\t\t\t\t... commit\n\t\t\t}\n\t\t}\n\t}\n}\nfragment commit on Commit {\n\tid\n\tshortId\n\tauthor {\n\t\tname\n\t\temail\n\t\ttime\n\t}\n\tcommitter {\n\t\tname\n\t\temail\n\t\ttime\n\t}\n\tmessage\n\tparents {\n\t\tshortId\n\t}\n\tdiff\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("revspec", revspec)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func TreeByRepoName(client *gqlclient.Client, ctx context.Context, name string, revspec string, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query treeByRepoName ($name: String!, $revspec: String!, $cursor: Cursor) {\n\tme {\n\t\trepository(name: $name) {\n\t\t\trevparse_single(revspec: $revspec) {\n\t\t\t\ttree {\n\t\t\t\t\t... tree\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment blob on Object {\n\ttype\n\t__typename\n\t... on TextBlob {\n\t\tsize\n\t\tcontent\n\t}\n\t... on BinaryBlob {\n\t\tsize\n\t\tcontent\n\t}\n}\nfragment tree on Tree {\n\tentries(cursor: $cursor) {\n\t\tresults {\n\t\t\tname\n\t\t\tmode\n\t\t\tobject {\n\t\t\t\t... blob\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("revspec", revspec)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func TreeByUser(client *gqlclient.Client, ctx context.Context, username string, name string, revspec string, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query treeByUser ($username: String!, $name: String!, $revspec: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepository(name: $name) {\n\t\t\trevparse_single(revspec: $revspec) {\n\t\t\t\ttree {\n\t\t\t\t\t... tree\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment tree on Tree {\n\tentries(cursor: $cursor) {\n\t\tresults {\n\t\t\tname\n\t\t\tmode\n\t\t\tobject {\n\t\t\t\t... blob\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\nfragment blob on Object {\n\ttype\n\t__typename\n\t... on TextBlob {\n\t\tsize\n\t\tcontent\n\t}\n\t... on BinaryBlob {\n\t\tsize\n\t\tcontent\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("revspec", revspec)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func PathByRepoName(client *gqlclient.Client, ctx context.Context, name string, revspec string, path string, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query pathByRepoName ($name: String!, $revspec: String!, $path: String!, $cursor: Cursor) {\n\tme {\n\t\trepository(name: $name) {\n\t\t\tpath(revspec: $revspec, path: $path) {\n\t\t\t\t... treeEntry\n\t\t\t}\n\t\t}\n\t}\n}\nfragment treeEntry on TreeEntry {\n\tname\n\tmode\n\tobject {\n\t\t... blob\n\t\t... on Tree {\n\t\t\t... tree\n\t\t}\n\t}\n}\nfragment blob on Object {\n\ttype\n\t__typename\n\t... on TextBlob {\n\t\tsize\n\t\tcontent\n\t}\n\t... on BinaryBlob {\n\t\tsize\n\t\tcontent\n\t}\n}\nfragment tree on Tree {\n\tentries(cursor: $cursor) {\n\t\tresults {\n\t\t\tname\n\t\t\tmode\n\t\t\tobject {\n\t\t\t\t... blob\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("revspec", revspec)
+	op.Var("path", path)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func PathByUser(client *gqlclient.Client, ctx context.Context, username string, name string, revspec string, path string, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query pathByUser ($username: String!, $name: String!, $revspec: String!, $path: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepository(name: $name) {\n\t\t\tpath(revspec: $revspec, path: $path) {\n\t\t\t\t... treeEntry\n\t\t\t}\n\t\t}\n\t}\n}\nfragment treeEntry on TreeEntry {\n\tname\n\tmode\n\tobject {\n\t\t... blob\n\t\t... on Tree {\n\t\t\t... tree\n\t\t}\n\t}\n}\nfragment blob on Object {\n\ttype\n\t__typename\n\t... on TextBlob {\n\t\tsize\n\t\tcontent\n\t}\n\t... on BinaryBlob {\n\t\tsize\n\t\tcontent\n\t}\n}\nfragment tree on Tree {\n\tentries(cursor: $cursor) {\n\t\tresults {\n\t\t\tname\n\t\t\tmode\n\t\t\tobject {\n\t\t\t\t... blob\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("revspec", revspec)
+	op.Var("path", path)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func ReferencesByRepoName(client *gqlclient.Client, ctx context.Context, name string, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query referencesByRepoName ($name: String!, $cursor: Cursor) {\n\tme {\n\t\trepository(name: $name) {\n\t\t\t... references\n\t\t}\n\t}\n}\nfragment references on Repository {\n\tHEAD {\n\t\tname\n\t}\n\treferences(cursor: $cursor) {\n\t\tresults {\n\t\t\tname\n\t\t\ttarget\n\t\t\tfollow {\n\t\t\t\ttype\n\t\t\t\tshortId\n\t\t\t\t__typename\n\t\t\t\t... on Commit {\n\t\t\t\t\tmessage\n\t\t\t\t}\n\t\t\t\t... on Tag {\n\t\t\t\t\ttagger {\n\t\t\t\t\t\tname\n\t\t\t\t\t\temail\n\t\t\t\t\t\ttime\n\t\t\t\t\t}\n\t\t\t\t\tmessage\n\t\t\t\t\ttarget {\n\t\t\t\t\t\ttype\n\t\t\t\t\t\tshortId\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t}\n\t\t\tartifacts {\n\t\t\t\tresults {\n\t\t\t\t\tid\n\t\t\t\t\tfilename\n\t\t\t\t\tsize\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func ReferencesByUser(client *gqlclient.Client, ctx context.Context, username string, name string, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query referencesByUser ($username: String!, $name: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepository(name: $name) {\n\t\t\t... references\n\t\t}\n\t}\n}\nfragment references on Repository {\n\tHEAD {\n\t\tname\n\t}\n\treferences(cursor: $cursor) {\n\t\tresults {\n\t\t\tname\n\t\t\ttarget\n\t\t\tfollow {\n\t\t\t\ttype\n\t\t\t\tshortId\n\t\t\t\t__typename\n\t\t\t\t... on Commit {\n\t\t\t\t\tmessage\n\t\t\t\t}\n\t\t\t\t... on Tag {\n\t\t\t\t\ttagger {\n\t\t\t\t\t\tname\n\t\t\t\t\t\temail\n\t\t\t\t\t\ttime\n\t\t\t\t\t}\n\t\t\t\t\tmessage\n\t\t\t\t\ttarget {\n\t\t\t\t\t\ttype\n\t\t\t\t\t\tshortId\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t}\n\t\t\tartifacts {\n\t\t\t\tresults {\n\t\t\t\t\tid\n\t\t\t\t\tfilename\n\t\t\t\t\tsize\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func ReferenceByRepoName(client *gqlclient.Client, ctx context.Context, name string, ref string) (me *User, err error) {
+	op := gqlclient.NewOperation("query referenceByRepoName ($name: String!, $ref: String!) {\n\tme {\n\t\trepository(name: $name) {\n\t\t\treference(name: $ref) {\n\t\t\t\tname\n\t\t\t\ttarget\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("ref", ref)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func ReferenceByUser(client *gqlclient.Client, ctx context.Context, username string, name string, ref string) (user *User, err error) {
+	op := gqlclient.NewOperation("query referenceByUser ($username: String!, $name: String!, $ref: String!) {\n\tuser(username: $username) {\n\t\trepository(name: $name) {\n\t\t\treference(name: $ref) {\n\t\t\t\tname\n\t\t\t\ttarget\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("ref", ref)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func UserWebhookDeliveries(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookDeliveries ($id: Int!, $cursor: Cursor) {\n\tuserWebhook(id: $id) {\n\t\tdeliveries(cursor: $cursor) {\n\t\t\t... deliveries\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
+
+func GitWebhookDeliveries(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (gitWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query gitWebhookDeliveries ($id: Int!, $cursor: Cursor) {\n\tgitWebhook(id: $id) {\n\t\tdeliveries(cursor: $cursor) {\n\t\t\t... deliveries\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		GitWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.GitWebhook, err
+}
+
+func UserWebhookSample(client *gqlclient.Client, ctx context.Context, id int32, event WebhookEvent) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookSample ($id: Int!, $event: WebhookEvent!) {\n\tuserWebhook(id: $id) {\n\t\tsample(event: $event)\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
+
+func GitWebhookSample(client *gqlclient.Client, ctx context.Context, id int32, event WebhookEvent) (gitWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query gitWebhookSample ($id: Int!, $event: WebhookEvent!) {\n\tgitWebhook(id: $id) {\n\t\tsample(event: $event)\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		GitWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.GitWebhook, err
+}
+
+func MirrorTarget(client *gqlclient.Client, ctx context.Context, name string) (me *User, err error) {
+	op := gqlclient.NewOperation("query mirrorTarget ($name: String!) {\n\tme {\n\t\tcanonicalName\n\t\trepository(name: $name) {\n\t\t\tid\n\t\t}\n\t}\n}\n")
+	op.Var("name", name)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func CloneRepository(client *gqlclient.Client, ctx context.Context, name string) (me *User, err error) {
+	op := gqlclient.NewOperation("query cloneRepository ($name: String!) {\n\tme {\n\t\tcanonicalName\n\t\trepository(name: $name) {\n\t\t\tname\n\t\t}\n\t}\n}\n")
+	op.Var("name", name)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func CloneRepositoryByUser(client *gqlclient.Client, ctx context.Context, username string, name string) (user *User, err error) {
+	op := gqlclient.NewOperation("query cloneRepositoryByUser ($username: String!, $name: String!) {\n\tuser(username: $username) {\n\t\tcanonicalName\n\t\trepository(name: $name) {\n\t\t\tname\n\t\t}\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
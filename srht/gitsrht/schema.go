@@ -0,0 +1,8 @@
+package gitsrht
+
+import _ "embed"
+
+// Schema is the GraphQL schema of git.sr.ht.
+//
+//go:embed schema.graphqls
+var Schema string
@@ -437,7 +437,7 @@ func RepositoryIDByUser(client *gqlclient.Client, ctx context.Context, username
 }
 
 func Repositories(client *gqlclient.Client, ctx context.Context, cursor *Cursor) (repositories *RepositoryCursor, err error) {
-	op := gqlclient.NewOperation("query repositories ($cursor: Cursor) {\n\trepositories(cursor: $cursor) {\n\t\t... repos\n\t}\n}\nfragment repos on RepositoryCursor {\n\tresults {\n\t\tname\n\t\tdescription\n\t\tvisibility\n\t\towner {\n\t\t\tcanonicalName\n\t\t}\n\t}\n\tcursor\n}\n")
+	op := gqlclient.NewOperation("query repositories ($cursor: Cursor) {\n\trepositories(cursor: $cursor) {\n\t\t... repos\n\t}\n}\nfragment repos on RepositoryCursor {\n\tresults {\n\t\tid\n\t\tname\n\t\tdescription\n\t\tvisibility\n\t\towner {\n\t\t\tcanonicalName\n\t\t}\n\t}\n\tcursor\n}\n")
 	op.Var("cursor", cursor)
 	var respData struct {
 		Repositories *RepositoryCursor
@@ -447,7 +447,7 @@ func Repositories(client *gqlclient.Client, ctx context.Context, cursor *Cursor)
 }
 
 func RepositoriesByUser(client *gqlclient.Client, ctx context.Context, username string, cursor *Cursor) (user *User, err error) {
-	op := gqlclient.NewOperation("query repositoriesByUser ($username: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepositories(cursor: $cursor) {\n\t\t\t... repos\n\t\t}\n\t}\n}\nfragment repos on RepositoryCursor {\n\tresults {\n\t\tname\n\t\tdescription\n\t\tvisibility\n\t\towner {\n\t\t\tcanonicalName\n\t\t}\n\t}\n\tcursor\n}\n")
+	op := gqlclient.NewOperation("query repositoriesByUser ($username: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\trepositories(cursor: $cursor) {\n\t\t\t... repos\n\t\t}\n\t}\n}\nfragment repos on RepositoryCursor {\n\tresults {\n\t\tid\n\t\tname\n\t\tdescription\n\t\tvisibility\n\t\towner {\n\t\t\tcanonicalName\n\t\t}\n\t}\n\tcursor\n}\n")
 	op.Var("username", username)
 	op.Var("cursor", cursor)
 	var respData struct {
@@ -623,3 +623,25 @@ func DeleteACL(client *gqlclient.Client, ctx context.Context, id int32) (deleteA
 	err = client.Execute(ctx, op, &respData)
 	return respData.DeleteACL, err
 }
+
+func UserWebhookDeliveries(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookDeliveries ($id: Int!, $cursor: Cursor) {\n\tuserWebhook(id: $id) {\n\t\tdeliveries(cursor: $cursor) {\n\t\t\t... deliveries\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
+
+func UserWebhookSample(client *gqlclient.Client, ctx context.Context, id int32, event WebhookEvent) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookSample ($id: Int!, $event: WebhookEvent!) {\n\tuserWebhook(id: $id) {\n\t\tsample(event: $event)\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
@@ -0,0 +1,8 @@
+package hgsrht
+
+import _ "embed"
+
+// Schema is the GraphQL schema of hg.sr.ht.
+//
+//go:embed schema.graphqls
+var Schema string
@@ -799,7 +799,7 @@ func MailingListIDByUser(client *gqlclient.Client, ctx context.Context, username
 }
 
 func ListPatches(client *gqlclient.Client, ctx context.Context, name string, cursor *Cursor) (me *User, err error) {
-	op := gqlclient.NewOperation("query listPatches ($name: String!, $cursor: Cursor) {\n\tme {\n\t\tlist(name: $name) {\n\t\t\t... patchsetsByList\n\t\t}\n\t}\n}\nfragment patchsetsByList on MailingList {\n\tpatches(cursor: $cursor) {\n\t\tresults {\n\t\t\tid\n\t\t\tsubject\n\t\t\tstatus\n\t\t\tcreated\n\t\t\tversion\n\t\t\tprefix\n\t\t\tsubmitter {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op := gqlclient.NewOperation("query listPatches ($name: String!, $cursor: Cursor) {\n\tme {\n\t\tlist(name: $name) {\n\t\t\t... patchsetsByList\n\t\t}\n\t}\n}\nfragment patchsetsByList on MailingList {\n\tpatches(cursor: $cursor) {\n\t\tresults {\n\t\t\tid\n\t\t\tsubject\n\t\t\tstatus\n\t\t\tcreated\n\t\t\tversion\n\t\t\tprefix\n\t\t\tsubmitter {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t\ttools {\n\t\t\t\ticon\n\t\t\t\tdetails\n\t\t\t}\n\t\t\tsupersededBy {\n\t\t\t\tid\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
 	op.Var("name", name)
 	op.Var("cursor", cursor)
 	var respData struct {
@@ -810,7 +810,7 @@ func ListPatches(client *gqlclient.Client, ctx context.Context, name string, cur
 }
 
 func ListPatchesByUser(client *gqlclient.Client, ctx context.Context, username string, name string, cursor *Cursor) (user *User, err error) {
-	op := gqlclient.NewOperation("query listPatchesByUser ($username: String!, $name: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\tlist(name: $name) {\n\t\t\t... patchsetsByList\n\t\t}\n\t}\n}\nfragment patchsetsByList on MailingList {\n\tpatches(cursor: $cursor) {\n\t\tresults {\n\t\t\tid\n\t\t\tsubject\n\t\t\tstatus\n\t\t\tcreated\n\t\t\tversion\n\t\t\tprefix\n\t\t\tsubmitter {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op := gqlclient.NewOperation("query listPatchesByUser ($username: String!, $name: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\tlist(name: $name) {\n\t\t\t... patchsetsByList\n\t\t}\n\t}\n}\nfragment patchsetsByList on MailingList {\n\tpatches(cursor: $cursor) {\n\t\tresults {\n\t\t\tid\n\t\t\tsubject\n\t\t\tstatus\n\t\t\tcreated\n\t\t\tversion\n\t\t\tprefix\n\t\t\tsubmitter {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t\ttools {\n\t\t\t\ticon\n\t\t\t\tdetails\n\t\t\t}\n\t\t\tsupersededBy {\n\t\t\t\tid\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
 	op.Var("username", username)
 	op.Var("name", name)
 	op.Var("cursor", cursor)
@@ -822,7 +822,7 @@ func ListPatchesByUser(client *gqlclient.Client, ctx context.Context, username s
 }
 
 func Patches(client *gqlclient.Client, ctx context.Context, cursor *Cursor) (me *User, err error) {
-	op := gqlclient.NewOperation("query patches ($cursor: Cursor) {\n\tme {\n\t\t... patchsets\n\t}\n}\nfragment patchsets on User {\n\tpatches(cursor: $cursor) {\n\t\tresults {\n\t\t\tid\n\t\t\tsubject\n\t\t\tstatus\n\t\t\tcreated\n\t\t\tversion\n\t\t\tprefix\n\t\t\tlist {\n\t\t\t\tname\n\t\t\t\towner {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op := gqlclient.NewOperation("query patches ($cursor: Cursor) {\n\tme {\n\t\tcanonicalName\n\t\t... patchsets\n\t}\n}\nfragment patchsets on User {\n\tpatches(cursor: $cursor) {\n\t\tresults {\n\t\t\tid\n\t\t\tsubject\n\t\t\tstatus\n\t\t\tcreated\n\t\t\tversion\n\t\t\tprefix\n\t\t\tlist {\n\t\t\t\tname\n\t\t\t\towner {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\ttools {\n\t\t\t\ticon\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
 	op.Var("cursor", cursor)
 	var respData struct {
 		Me *User
@@ -832,7 +832,7 @@ func Patches(client *gqlclient.Client, ctx context.Context, cursor *Cursor) (me
 }
 
 func PatchesByUser(client *gqlclient.Client, ctx context.Context, username string, cursor *Cursor) (user *User, err error) {
-	op := gqlclient.NewOperation("query patchesByUser ($username: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\t... patchsets\n\t}\n}\nfragment patchsets on User {\n\tpatches(cursor: $cursor) {\n\t\tresults {\n\t\t\tid\n\t\t\tsubject\n\t\t\tstatus\n\t\t\tcreated\n\t\t\tversion\n\t\t\tprefix\n\t\t\tlist {\n\t\t\t\tname\n\t\t\t\towner {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op := gqlclient.NewOperation("query patchesByUser ($username: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\t... patchsets\n\t}\n}\nfragment patchsets on User {\n\tpatches(cursor: $cursor) {\n\t\tresults {\n\t\t\tid\n\t\t\tsubject\n\t\t\tstatus\n\t\t\tcreated\n\t\t\tversion\n\t\t\tprefix\n\t\t\tlist {\n\t\t\t\tname\n\t\t\t\towner {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\ttools {\n\t\t\t\ticon\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
 	op.Var("username", username)
 	op.Var("cursor", cursor)
 	var respData struct {
@@ -842,10 +842,19 @@ func PatchesByUser(client *gqlclient.Client, ctx context.Context, username strin
 	return respData.User, err
 }
 
-func PatchsetById(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (patchset *Patchset, err error) {
-	op := gqlclient.NewOperation("query patchsetById ($id: Int!, $cursor: Cursor) {\n\tpatchset(id: $id) {\n\t\tpatches(cursor: $cursor) {\n\t\t\tresults {\n\t\t\t\tdate\n\t\t\t\tbody\n\t\t\t\tsubject\n\t\t\t\theader(want: \"From\")\n\t\t\t}\n\t\t\tcursor\n\t\t}\n\t}\n}\n")
+func PatchsetMbox(client *gqlclient.Client, ctx context.Context, id int32) (patchset *Patchset, err error) {
+	op := gqlclient.NewOperation("query patchsetMbox ($id: Int!) {\n\tpatchset(id: $id) {\n\t\tid\n\t\tmbox\n\t\tlist {\n\t\t\tname\n\t\t\towner {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t}\n\t\tcoverLetter {\n\t\t\tdate\n\t\t\tsubject\n\t\t\tbody\n\t\t\tenvelope\n\t\t}\n\t\ttools {\n\t\t\ticon\n\t\t\tdetails\n\t\t}\n\t}\n}\n")
+	op.Var("id", id)
+	var respData struct {
+		Patchset *Patchset
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Patchset, err
+}
+
+func PatchsetTools(client *gqlclient.Client, ctx context.Context, id int32) (patchset *Patchset, err error) {
+	op := gqlclient.NewOperation("query patchsetTools ($id: Int!) {\n\tpatchset(id: $id) {\n\t\ttools {\n\t\t\tid\n\t\t\tupdated\n\t\t\ticon\n\t\t\tdetails\n\t\t}\n\t}\n}\n")
 	op.Var("id", id)
-	op.Var("cursor", cursor)
 	var respData struct {
 		Patchset *Patchset
 	}
@@ -928,6 +937,29 @@ func ArchiveByUser(client *gqlclient.Client, ctx context.Context, username strin
 	return respData.User, err
 }
 
+func SyncEmails(client *gqlclient.Client, ctx context.Context, name string, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query syncEmails ($name: String!, $cursor: Cursor) {\n\tme {\n\t\tlist(name: $name) {\n\t\t\t... syncEmails\n\t\t}\n\t}\n}\nfragment syncEmails on MailingList {\n\tname\n\towner {\n\t\tcanonicalName\n\t}\n\tarchive\n\temails(cursor: $cursor) {\n\t\tresults {\n\t\t\treceived\n\t\t\tmessageID\n\t\t\tenvelope\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func SyncEmailsByUser(client *gqlclient.Client, ctx context.Context, username string, name string, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query syncEmailsByUser ($username: String!, $name: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\tlist(name: $name) {\n\t\t\t... syncEmails\n\t\t}\n\t}\n}\nfragment syncEmails on MailingList {\n\tname\n\towner {\n\t\tcanonicalName\n\t}\n\tarchive\n\temails(cursor: $cursor) {\n\t\tresults {\n\t\t\treceived\n\t\t\tmessageID\n\t\t\tenvelope\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
 func CompleteLists(client *gqlclient.Client, ctx context.Context) (me *User, err error) {
 	op := gqlclient.NewOperation("query completeLists {\n\tme {\n\t\tlists {\n\t\t\tresults {\n\t\t\t\tname\n\t\t\t}\n\t\t}\n\t}\n}\n")
 	var respData struct {
@@ -1022,6 +1054,30 @@ func UpdatePatchset(client *gqlclient.Client, ctx context.Context, id int32, sta
 	return respData.UpdatePatchset, err
 }
 
+func CreateTool(client *gqlclient.Client, ctx context.Context, patchsetID int32, details string, icon ToolIcon) (createTool *PatchsetTool, err error) {
+	op := gqlclient.NewOperation("mutation createTool ($patchsetID: Int!, $details: String!, $icon: ToolIcon!) {\n\tcreateTool(patchsetID: $patchsetID, details: $details, icon: $icon) {\n\t\tid\n\t}\n}\n")
+	op.Var("patchsetID", patchsetID)
+	op.Var("details", details)
+	op.Var("icon", icon)
+	var respData struct {
+		CreateTool *PatchsetTool
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.CreateTool, err
+}
+
+func UpdateTool(client *gqlclient.Client, ctx context.Context, id int32, details *string, icon *ToolIcon) (updateTool *PatchsetTool, err error) {
+	op := gqlclient.NewOperation("mutation updateTool ($id: Int!, $details: String, $icon: ToolIcon) {\n\tupdateTool(id: $id, details: $details, icon: $icon) {\n\t\tid\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("details", details)
+	op.Var("icon", icon)
+	var respData struct {
+		UpdateTool *PatchsetTool
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UpdateTool, err
+}
+
 func DeleteACL(client *gqlclient.Client, ctx context.Context, id int32) (deleteACL *MailingListACL, err error) {
 	op := gqlclient.NewOperation("mutation deleteACL ($id: Int!) {\n\tdeleteACL(id: $id) {\n\t\tentity {\n\t\t\tcanonicalName\n\t\t}\n\t\tlist {\n\t\t\tname\n\t\t}\n\t}\n}\n")
 	op.Var("id", id)
@@ -1116,3 +1172,185 @@ func DeleteMailingListWebhook(client *gqlclient.Client, ctx context.Context, id
 	err = client.Execute(ctx, op, &respData)
 	return respData.DeleteMailingListWebhook, err
 }
+
+func UserWebhookDeliveries(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookDeliveries ($id: Int!, $cursor: Cursor) {\n\tuserWebhook(id: $id) {\n\t\tdeliveries(cursor: $cursor) {\n\t\t\t... deliveries\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
+
+func MailingListWebhookDeliveries(client *gqlclient.Client, ctx context.Context, name string, id int32, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query mailingListWebhookDeliveries ($name: String!, $id: Int!, $cursor: Cursor) {\n\tme {\n\t\tlist(name: $name) {\n\t\t\twebhook(id: $id) {\n\t\t\t\tdeliveries(cursor: $cursor) {\n\t\t\t\t\t... deliveries\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("name", name)
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func MailingListWebhookDeliveriesByUser(client *gqlclient.Client, ctx context.Context, username string, name string, id int32, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query mailingListWebhookDeliveriesByUser ($username: String!, $name: String!, $id: Int!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\tlist(name: $name) {\n\t\t\twebhook(id: $id) {\n\t\t\t\tdeliveries(cursor: $cursor) {\n\t\t\t\t\t... deliveries\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func UserWebhookSample(client *gqlclient.Client, ctx context.Context, id int32, event WebhookEvent) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookSample ($id: Int!, $event: WebhookEvent!) {\n\tuserWebhook(id: $id) {\n\t\tsample(event: $event)\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
+
+func MailingListWebhookSample(client *gqlclient.Client, ctx context.Context, name string, id int32, event WebhookEvent) (me *User, err error) {
+	op := gqlclient.NewOperation("query mailingListWebhookSample ($name: String!, $id: Int!, $event: WebhookEvent!) {\n\tme {\n\t\tlist(name: $name) {\n\t\t\twebhook(id: $id) {\n\t\t\t\tsample(event: $event)\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func MailingListWebhookSampleByUser(client *gqlclient.Client, ctx context.Context, username string, name string, id int32, event WebhookEvent) (user *User, err error) {
+	op := gqlclient.NewOperation("query mailingListWebhookSampleByUser ($username: String!, $name: String!, $id: Int!, $event: WebhookEvent!) {\n\tuser(username: $username) {\n\t\tlist(name: $name) {\n\t\t\twebhook(id: $id) {\n\t\t\t\tsample(event: $event)\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func UserIDByName(client *gqlclient.Client, ctx context.Context, username string) (user *User, err error) {
+	op := gqlclient.NewOperation("query userIDByName ($username: String!) {\n\tuser(username: $username) {\n\t\tid\n\t}\n}\n")
+	op.Var("username", username)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func UpdateUserACL(client *gqlclient.Client, ctx context.Context, listId int32, userId int32, input ACLInput) (updateUserACL *MailingListACL, err error) {
+	op := gqlclient.NewOperation("mutation updateUserACL ($listId: Int!, $userId: Int!, $input: ACLInput!) {\n\tupdateUserACL(listID: $listId, userID: $userId, input: $input) {\n\t\tid\n\t\tentity {\n\t\t\tcanonicalName\n\t\t}\n\t}\n}\n")
+	op.Var("listId", listId)
+	op.Var("userId", userId)
+	op.Var("input", input)
+	var respData struct {
+		UpdateUserACL *MailingListACL
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UpdateUserACL, err
+}
+
+func UpdateSenderACL(client *gqlclient.Client, ctx context.Context, listId int32, address string, input ACLInput) (updateSenderACL *MailingListACL, err error) {
+	op := gqlclient.NewOperation("mutation updateSenderACL ($listId: Int!, $address: String!, $input: ACLInput!) {\n\tupdateSenderACL(listID: $listId, address: $address, input: $input) {\n\t\tid\n\t\tentity {\n\t\t\tcanonicalName\n\t\t}\n\t}\n}\n")
+	op.Var("listId", listId)
+	op.Var("address", address)
+	op.Var("input", input)
+	var respData struct {
+		UpdateSenderACL *MailingListACL
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UpdateSenderACL, err
+}
+
+func UpdateMailingListACL(client *gqlclient.Client, ctx context.Context, listId int32, input ACLInput) (updateMailingListACL *MailingList, err error) {
+	op := gqlclient.NewOperation("mutation updateMailingListACL ($listId: Int!, $input: ACLInput!) {\n\tupdateMailingListACL(listID: $listId, input: $input) {\n\t\tname\n\t}\n}\n")
+	op.Var("listId", listId)
+	op.Var("input", input)
+	var respData struct {
+		UpdateMailingListACL *MailingList
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UpdateMailingListACL, err
+}
+
+func Threads(client *gqlclient.Client, ctx context.Context, name string, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query threads ($name: String!, $cursor: Cursor) {\n\tme {\n\t\tlist(name: $name) {\n\t\t\t... threads\n\t\t}\n\t}\n}\nfragment threads on MailingList {\n\tthreads(cursor: $cursor) {\n\t\tresults {\n\t\t\tsubject\n\t\t\treplies\n\t\t\tparticipants\n\t\t\tupdated\n\t\t\tsender {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t\troot {\n\t\t\t\tid\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func ThreadsByUser(client *gqlclient.Client, ctx context.Context, username string, name string, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query threadsByUser ($username: String!, $name: String!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\tlist(name: $name) {\n\t\t\t... threads\n\t\t}\n\t}\n}\nfragment threads on MailingList {\n\tthreads(cursor: $cursor) {\n\t\tresults {\n\t\t\tsubject\n\t\t\treplies\n\t\t\tparticipants\n\t\t\tupdated\n\t\t\tsender {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t\troot {\n\t\t\t\tid\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func ThreadByEmail(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (email *Email, err error) {
+	op := gqlclient.NewOperation("query threadByEmail ($id: Int!, $cursor: Cursor) {\n\temail(id: $id) {\n\t\tthread {\n\t\t\t... thread\n\t\t}\n\t}\n}\nfragment thread on Thread {\n\tsubject\n\troot {\n\t\t... threadEmail\n\t}\n\tdescendants(cursor: $cursor) {\n\t\tresults {\n\t\t\t... threadEmail\n\t\t\tparent {\n\t\t\t\tid\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\nfragment threadEmail on Email {\n\tid\n\tsubject\n\tdate\n\tbody\n\tsender {\n\t\tcanonicalName\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Email *Email
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Email, err
+}
+
+func ThreadByMessage(client *gqlclient.Client, ctx context.Context, messageID string, cursor *Cursor) (message *Email, err error) {
+	op := gqlclient.NewOperation("query threadByMessage ($messageID: String!, $cursor: Cursor) {\n\tmessage(messageID: $messageID) {\n\t\tthread {\n\t\t\t... thread\n\t\t}\n\t}\n}\nfragment thread on Thread {\n\tsubject\n\troot {\n\t\t... threadEmail\n\t}\n\tdescendants(cursor: $cursor) {\n\t\tresults {\n\t\t\t... threadEmail\n\t\t\tparent {\n\t\t\t\tid\n\t\t\t}\n\t\t}\n\t\tcursor\n\t}\n}\nfragment threadEmail on Email {\n\tid\n\tsubject\n\tdate\n\tbody\n\tsender {\n\t\tcanonicalName\n\t}\n}\n")
+	op.Var("messageID", messageID)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Message *Email
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Message, err
+}
+
+func MessageByID(client *gqlclient.Client, ctx context.Context, messageID string) (message *Email, err error) {
+	op := gqlclient.NewOperation("query messageByID ($messageID: String!) {\n\tmessage(messageID: $messageID) {\n\t\tsubject\n\t\tmessageID\n\t\tdate\n\t\tbody\n\t\tenvelope\n\t\tlist {\n\t\t\tname\n\t\t\towner {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("messageID", messageID)
+	var respData struct {
+		Message *Email
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Message, err
+}
+
+func PatchsetInfo(client *gqlclient.Client, ctx context.Context, id int32) (patchset *Patchset, err error) {
+	op := gqlclient.NewOperation("query patchsetInfo ($id: Int!) {\n\tpatchset(id: $id) {\n\t\tid\n\t\tsubject\n\t\tversion\n\t\tmbox\n\t\tsubmitter {\n\t\t\tcanonicalName\n\t\t}\n\t\tlist {\n\t\t\tname\n\t\t\towner {\n\t\t\t\tcanonicalName\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("id", id)
+	var respData struct {
+		Patchset *Patchset
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Patchset, err
+}
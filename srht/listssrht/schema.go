@@ -0,0 +1,8 @@
+package listssrht
+
+import _ "embed"
+
+// Schema is the GraphQL schema of lists.sr.ht.
+//
+//go:embed schema.graphqls
+var Schema string
@@ -82,6 +82,62 @@ func ParsePatchsetStatus(s string) (PatchsetStatus, error) {
 	}
 }
 
+func (icon ToolIcon) TermString() string {
+	var style termfmt.Style
+
+	switch icon {
+	case ToolIconPending:
+		style = termfmt.Dim
+	case ToolIconWaiting:
+		style = termfmt.Yellow
+	case ToolIconSuccess:
+		style = termfmt.Green
+	case ToolIconFailed:
+		style = termfmt.Red
+	case ToolIconCancelled:
+		style = termfmt.Dim
+	default:
+		panic(fmt.Sprintf("unknown tool icon: %q", icon))
+	}
+
+	return style.String(strings.ToLower(string(icon)))
+}
+
+// TermIcon returns a single-character representation of the icon.
+func (icon ToolIcon) TermIcon() string {
+	switch icon {
+	case ToolIconPending:
+		return termfmt.Dim.String("○")
+	case ToolIconWaiting:
+		return termfmt.Yellow.String("◔")
+	case ToolIconSuccess:
+		return termfmt.Green.String("✔")
+	case ToolIconFailed:
+		return termfmt.Red.String("✗")
+	case ToolIconCancelled:
+		return termfmt.Dim.String("⊘")
+	default:
+		panic(fmt.Sprintf("unknown tool icon: %q", icon))
+	}
+}
+
+func ParseToolIcon(s string) (ToolIcon, error) {
+	switch strings.ToLower(s) {
+	case "pending":
+		return ToolIconPending, nil
+	case "waiting":
+		return ToolIconWaiting, nil
+	case "success":
+		return ToolIconSuccess, nil
+	case "failed":
+		return ToolIconFailed, nil
+	case "cancelled":
+		return ToolIconCancelled, nil
+	default:
+		return "", fmt.Errorf("invalid tool icon: %s", s)
+	}
+}
+
 func (acl GeneralACL) TermString() string {
 	return fmt.Sprintf("%s browse  %s reply  %s post  %s moderate",
 		PermissionIcon(acl.Browse), PermissionIcon(acl.Reply), PermissionIcon(acl.Post), PermissionIcon(acl.Moderate))
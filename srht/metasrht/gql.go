@@ -758,6 +758,15 @@ func ListSSHKeysByUser(client *gqlclient.Client, ctx context.Context, username s
 	return respData.UserByName, err
 }
 
+func SshKeyIDs(client *gqlclient.Client, ctx context.Context) (me *User, err error) {
+	op := gqlclient.NewOperation("query sshKeyIDs {\n\tme {\n\t\tsshKeys {\n\t\t\tresults {\n\t\t\t\tid\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
 func ListRawSSHKeys(client *gqlclient.Client, ctx context.Context, cursor *Cursor) (me *User, err error) {
 	op := gqlclient.NewOperation("query listRawSSHKeys ($cursor: Cursor) {\n\tme {\n\t\t... sshKeysRaw\n\t}\n}\nfragment sshKeysRaw on User {\n\tsshKeys(cursor: $cursor) {\n\t\tresults {\n\t\t\tkey\n\t\t}\n\t\tcursor\n\t}\n}\n")
 	op.Var("cursor", cursor)
@@ -955,3 +964,25 @@ func ClearBio(client *gqlclient.Client, ctx context.Context) (updateUser *User,
 	err = client.Execute(ctx, op, &respData)
 	return respData.UpdateUser, err
 }
+
+func ProfileWebhookDeliveries(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (profileWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query profileWebhookDeliveries ($id: Int!, $cursor: Cursor) {\n\tprofileWebhook(id: $id) {\n\t\tdeliveries(cursor: $cursor) {\n\t\t\t... deliveries\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		ProfileWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.ProfileWebhook, err
+}
+
+func ProfileWebhookSample(client *gqlclient.Client, ctx context.Context, id int32, event WebhookEvent) (profileWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query profileWebhookSample ($id: Int!, $event: WebhookEvent!) {\n\tprofileWebhook(id: $id) {\n\t\tsample(event: $event)\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		ProfileWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.ProfileWebhook, err
+}
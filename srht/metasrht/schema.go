@@ -0,0 +1,8 @@
+package metasrht
+
+import _ "embed"
+
+// Schema is the GraphQL schema of meta.sr.ht.
+//
+//go:embed schema.graphqls
+var Schema string
@@ -456,7 +456,7 @@ func UserID(client *gqlclient.Client, ctx context.Context, username string) (use
 }
 
 func Acls(client *gqlclient.Client, ctx context.Context, domain string, protocol Protocol, cursor *Cursor) (site *Site, err error) {
-	op := gqlclient.NewOperation("query acls ($domain: String!, $protocol: Protocol!, $cursor: Cursor) {\n\tsite(domain: $domain, protocol: $protocol) {\n\t\tacls(cursor: $cursor) {\n\t\t\tresults {\n\t\t\t\tid\n\t\t\t\tcreated\n\t\t\t\tentity {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tpublish\n\t\t\t}\n\t\t\tcursor\n\t\t}\n\t}\n}\n")
+	op := gqlclient.NewOperation("query acls ($domain: String!, $protocol: Protocol!, $cursor: Cursor) {\n\tsite(domain: $domain, protocol: $protocol) {\n\t\tid\n\t\tacls(cursor: $cursor) {\n\t\t\tresults {\n\t\t\t\tid\n\t\t\t\tcreated\n\t\t\t\tentity {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tpublish\n\t\t\t}\n\t\t\tcursor\n\t\t}\n\t}\n}\n")
 	op.Var("domain", domain)
 	op.Var("protocol", protocol)
 	op.Var("cursor", cursor)
@@ -466,3 +466,25 @@ func Acls(client *gqlclient.Client, ctx context.Context, domain string, protocol
 	err = client.Execute(ctx, op, &respData)
 	return respData.Site, err
 }
+
+func UserWebhookDeliveries(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookDeliveries ($id: Int!, $cursor: Cursor) {\n\tuserWebhook(id: $id) {\n\t\tdeliveries(cursor: $cursor) {\n\t\t\t... deliveries\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
+
+func UserWebhookSample(client *gqlclient.Client, ctx context.Context, id int32, event WebhookEvent) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookSample ($id: Int!, $event: WebhookEvent!) {\n\tuserWebhook(id: $id) {\n\t\tsample(event: $event)\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
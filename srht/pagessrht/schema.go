@@ -0,0 +1,8 @@
+package pagessrht
+
+import _ "embed"
+
+// Schema is the GraphQL schema of pages.sr.ht.
+//
+//go:embed schema.graphqls
+var Schema string
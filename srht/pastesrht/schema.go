@@ -0,0 +1,8 @@
+package pastesrht
+
+import _ "embed"
+
+// Schema is the GraphQL schema of paste.sr.ht.
+//
+//go:embed schema.graphqls
+var Schema string
@@ -1198,7 +1198,7 @@ func TrackerWebhooksByUser(client *gqlclient.Client, ctx context.Context, userna
 }
 
 func TicketByName(client *gqlclient.Client, ctx context.Context, name string, id int32) (me *User, err error) {
-	op := gqlclient.NewOperation("query ticketByName ($name: String!, $id: Int!) {\n\tme {\n\t\ttracker(name: $name) {\n\t\t\tticket(id: $id) {\n\t\t\t\t... ticket\n\t\t\t}\n\t\t}\n\t}\n}\nfragment ticket on Ticket {\n\tcreated\n\tupdated\n\tsubmitter {\n\t\tcanonicalName\n\t}\n\tsubject\n\tbody\n\tstatus\n\tresolution\n\tlabels {\n\t\tname\n\t\tbackgroundColor\n\t\tforegroundColor\n\t}\n\tassignees {\n\t\tcanonicalName\n\t}\n\tevents {\n\t\tresults {\n\t\t\tcreated\n\t\t\tchanges {\n\t\t\t\t__typename\n\t\t\t\t... on Comment {\n\t\t\t\t\tauthor {\n\t\t\t\t\t\tcanonicalName\n\t\t\t\t\t}\n\t\t\t\t\ttext\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op := gqlclient.NewOperation("query ticketByName ($name: String!, $id: Int!) {\n\tme {\n\t\ttracker(name: $name) {\n\t\t\tticket(id: $id) {\n\t\t\t\t... ticket\n\t\t\t}\n\t\t}\n\t}\n}\nfragment ticket on Ticket {\n\tref\n\tcreated\n\tupdated\n\tsubmitter {\n\t\tcanonicalName\n\t}\n\tsubject\n\tbody\n\tstatus\n\tresolution\n\tlabels {\n\t\tname\n\t\tbackgroundColor\n\t\tforegroundColor\n\t}\n\tassignees {\n\t\tcanonicalName\n\t}\n\tevents {\n\t\t... ticketEvents\n\t}\n}\nfragment ticketEvents on EventCursor {\n\tresults {\n\t\tcreated\n\t\tchanges {\n\t\t\t__typename\n\t\t\teventType\n\t\t\t... on Comment {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\ttext\n\t\t\t}\n\t\t\t... on StatusChange {\n\t\t\t\teditor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\toldStatus\n\t\t\t\tnewStatus\n\t\t\t\toldResolution\n\t\t\t\tnewResolution\n\t\t\t}\n\t\t\t... on LabelUpdate {\n\t\t\t\tlabeler {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tlabel {\n\t\t\t\t\tname\n\t\t\t\t\tbackgroundColor\n\t\t\t\t\tforegroundColor\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on Assignment {\n\t\t\t\tassigner {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tassignee {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on UserMention {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tmentioned {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on TicketMention {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tticket {\n\t\t\t\t\tref\n\t\t\t\t}\n\t\t\t\tmentioned {\n\t\t\t\t\tref\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n\tcursor\n}\n")
 	op.Var("name", name)
 	op.Var("id", id)
 	var respData struct {
@@ -1209,7 +1209,7 @@ func TicketByName(client *gqlclient.Client, ctx context.Context, name string, id
 }
 
 func TicketByUser(client *gqlclient.Client, ctx context.Context, username string, tracker string, id int32) (user *User, err error) {
-	op := gqlclient.NewOperation("query ticketByUser ($username: String!, $tracker: String!, $id: Int!) {\n\tuser(username: $username) {\n\t\ttracker(name: $tracker) {\n\t\t\tticket(id: $id) {\n\t\t\t\t... ticket\n\t\t\t}\n\t\t}\n\t}\n}\nfragment ticket on Ticket {\n\tcreated\n\tupdated\n\tsubmitter {\n\t\tcanonicalName\n\t}\n\tsubject\n\tbody\n\tstatus\n\tresolution\n\tlabels {\n\t\tname\n\t\tbackgroundColor\n\t\tforegroundColor\n\t}\n\tassignees {\n\t\tcanonicalName\n\t}\n\tevents {\n\t\tresults {\n\t\t\tcreated\n\t\t\tchanges {\n\t\t\t\t__typename\n\t\t\t\t... on Comment {\n\t\t\t\t\tauthor {\n\t\t\t\t\t\tcanonicalName\n\t\t\t\t\t}\n\t\t\t\t\ttext\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op := gqlclient.NewOperation("query ticketByUser ($username: String!, $tracker: String!, $id: Int!) {\n\tuser(username: $username) {\n\t\ttracker(name: $tracker) {\n\t\t\tticket(id: $id) {\n\t\t\t\t... ticket\n\t\t\t}\n\t\t}\n\t}\n}\nfragment ticket on Ticket {\n\tref\n\tcreated\n\tupdated\n\tsubmitter {\n\t\tcanonicalName\n\t}\n\tsubject\n\tbody\n\tstatus\n\tresolution\n\tlabels {\n\t\tname\n\t\tbackgroundColor\n\t\tforegroundColor\n\t}\n\tassignees {\n\t\tcanonicalName\n\t}\n\tevents {\n\t\t... ticketEvents\n\t}\n}\nfragment ticketEvents on EventCursor {\n\tresults {\n\t\tcreated\n\t\tchanges {\n\t\t\t__typename\n\t\t\teventType\n\t\t\t... on Comment {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\ttext\n\t\t\t}\n\t\t\t... on StatusChange {\n\t\t\t\teditor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\toldStatus\n\t\t\t\tnewStatus\n\t\t\t\toldResolution\n\t\t\t\tnewResolution\n\t\t\t}\n\t\t\t... on LabelUpdate {\n\t\t\t\tlabeler {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tlabel {\n\t\t\t\t\tname\n\t\t\t\t\tbackgroundColor\n\t\t\t\t\tforegroundColor\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on Assignment {\n\t\t\t\tassigner {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tassignee {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on UserMention {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tmentioned {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on TicketMention {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tticket {\n\t\t\t\t\tref\n\t\t\t\t}\n\t\t\t\tmentioned {\n\t\t\t\t\tref\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n\tcursor\n}\n")
 	op.Var("username", username)
 	op.Var("tracker", tracker)
 	op.Var("id", id)
@@ -1220,6 +1220,31 @@ func TicketByUser(client *gqlclient.Client, ctx context.Context, username string
 	return respData.User, err
 }
 
+func TicketEventsByName(client *gqlclient.Client, ctx context.Context, name string, id int32, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query ticketEventsByName ($name: String!, $id: Int!, $cursor: Cursor) {\n\tme {\n\t\ttracker(name: $name) {\n\t\t\tticket(id: $id) {\n\t\t\t\tevents(cursor: $cursor) {\n\t\t\t\t\t... ticketEvents\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment ticketEvents on EventCursor {\n\tresults {\n\t\tcreated\n\t\tchanges {\n\t\t\t__typename\n\t\t\teventType\n\t\t\t... on Comment {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\ttext\n\t\t\t}\n\t\t\t... on StatusChange {\n\t\t\t\teditor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\toldStatus\n\t\t\t\tnewStatus\n\t\t\t\toldResolution\n\t\t\t\tnewResolution\n\t\t\t}\n\t\t\t... on LabelUpdate {\n\t\t\t\tlabeler {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tlabel {\n\t\t\t\t\tname\n\t\t\t\t\tbackgroundColor\n\t\t\t\t\tforegroundColor\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on Assignment {\n\t\t\t\tassigner {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tassignee {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on UserMention {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tmentioned {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on TicketMention {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tticket {\n\t\t\t\t\tref\n\t\t\t\t}\n\t\t\t\tmentioned {\n\t\t\t\t\tref\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n\tcursor\n}\n")
+	op.Var("name", name)
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func TicketEventsByUser(client *gqlclient.Client, ctx context.Context, username string, tracker string, id int32, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query ticketEventsByUser ($username: String!, $tracker: String!, $id: Int!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\ttracker(name: $tracker) {\n\t\t\tticket(id: $id) {\n\t\t\t\tevents(cursor: $cursor) {\n\t\t\t\t\t... ticketEvents\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment ticketEvents on EventCursor {\n\tresults {\n\t\tcreated\n\t\tchanges {\n\t\t\t__typename\n\t\t\teventType\n\t\t\t... on Comment {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\ttext\n\t\t\t}\n\t\t\t... on StatusChange {\n\t\t\t\teditor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\toldStatus\n\t\t\t\tnewStatus\n\t\t\t\toldResolution\n\t\t\t\tnewResolution\n\t\t\t}\n\t\t\t... on LabelUpdate {\n\t\t\t\tlabeler {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tlabel {\n\t\t\t\t\tname\n\t\t\t\t\tbackgroundColor\n\t\t\t\t\tforegroundColor\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on Assignment {\n\t\t\t\tassigner {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tassignee {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on UserMention {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tmentioned {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t}\n\t\t\t... on TicketMention {\n\t\t\t\tauthor {\n\t\t\t\t\tcanonicalName\n\t\t\t\t}\n\t\t\t\tticket {\n\t\t\t\t\tref\n\t\t\t\t}\n\t\t\t\tmentioned {\n\t\t\t\t\tref\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n\tcursor\n}\n")
+	op.Var("username", username)
+	op.Var("tracker", tracker)
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
 func TicketBodyByName(client *gqlclient.Client, ctx context.Context, name string, id int32) (me *User, err error) {
 	op := gqlclient.NewOperation("query ticketBodyByName ($name: String!, $id: Int!) {\n\tme {\n\t\ttracker(name: $name) {\n\t\t\tid\n\t\t\tticket(id: $id) {\n\t\t\t\t... ticketBody\n\t\t\t}\n\t\t}\n\t}\n}\nfragment ticketBody on Ticket {\n\tid\n\tsubject\n\tbody\n}\n")
 	op.Var("name", name)
@@ -1664,3 +1689,141 @@ func ClearDescription(client *gqlclient.Client, ctx context.Context, id int32) (
 	err = client.Execute(ctx, op, &respData)
 	return respData.UpdateTracker, err
 }
+
+func UserWebhookDeliveries(client *gqlclient.Client, ctx context.Context, id int32, cursor *Cursor) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookDeliveries ($id: Int!, $cursor: Cursor) {\n\tuserWebhook(id: $id) {\n\t\tdeliveries(cursor: $cursor) {\n\t\t\t... deliveries\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
+
+func TrackerWebhookDeliveries(client *gqlclient.Client, ctx context.Context, name string, id int32, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query trackerWebhookDeliveries ($name: String!, $id: Int!, $cursor: Cursor) {\n\tme {\n\t\ttracker(name: $name) {\n\t\t\twebhook(id: $id) {\n\t\t\t\tdeliveries(cursor: $cursor) {\n\t\t\t\t\t... deliveries\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("name", name)
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func TrackerWebhookDeliveriesByUser(client *gqlclient.Client, ctx context.Context, username string, name string, id int32, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query trackerWebhookDeliveriesByUser ($username: String!, $name: String!, $id: Int!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\ttracker(name: $name) {\n\t\t\twebhook(id: $id) {\n\t\t\t\tdeliveries(cursor: $cursor) {\n\t\t\t\t\t... deliveries\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func TicketWebhookDeliveries(client *gqlclient.Client, ctx context.Context, name string, ticketId int32, id int32, cursor *Cursor) (me *User, err error) {
+	op := gqlclient.NewOperation("query ticketWebhookDeliveries ($name: String!, $ticketId: Int!, $id: Int!, $cursor: Cursor) {\n\tme {\n\t\ttracker(name: $name) {\n\t\t\tticket(id: $ticketId) {\n\t\t\t\twebhook(id: $id) {\n\t\t\t\t\tdeliveries(cursor: $cursor) {\n\t\t\t\t\t\t... deliveries\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("name", name)
+	op.Var("ticketId", ticketId)
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func TicketWebhookDeliveriesByUser(client *gqlclient.Client, ctx context.Context, username string, name string, ticketId int32, id int32, cursor *Cursor) (user *User, err error) {
+	op := gqlclient.NewOperation("query ticketWebhookDeliveriesByUser ($username: String!, $name: String!, $ticketId: Int!, $id: Int!, $cursor: Cursor) {\n\tuser(username: $username) {\n\t\ttracker(name: $name) {\n\t\t\tticket(id: $ticketId) {\n\t\t\t\twebhook(id: $id) {\n\t\t\t\t\tdeliveries(cursor: $cursor) {\n\t\t\t\t\t\t... deliveries\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\nfragment deliveries on WebhookDeliveryCursor {\n\tresults {\n\t\tuuid\n\t\tdate\n\t\tevent\n\t\trequestBody\n\t\tresponseBody\n\t\tresponseHeaders\n\t\tresponseStatus\n\t}\n\tcursor\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("ticketId", ticketId)
+	op.Var("id", id)
+	op.Var("cursor", cursor)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func UserWebhookSample(client *gqlclient.Client, ctx context.Context, id int32, event WebhookEvent) (userWebhook *WebhookSubscription, err error) {
+	op := gqlclient.NewOperation("query userWebhookSample ($id: Int!, $event: WebhookEvent!) {\n\tuserWebhook(id: $id) {\n\t\tsample(event: $event)\n\t}\n}\n")
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		UserWebhook *WebhookSubscription
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UserWebhook, err
+}
+
+func TrackerWebhookSample(client *gqlclient.Client, ctx context.Context, name string, id int32, event WebhookEvent) (me *User, err error) {
+	op := gqlclient.NewOperation("query trackerWebhookSample ($name: String!, $id: Int!, $event: WebhookEvent!) {\n\tme {\n\t\ttracker(name: $name) {\n\t\t\twebhook(id: $id) {\n\t\t\t\tsample(event: $event)\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func TrackerWebhookSampleByUser(client *gqlclient.Client, ctx context.Context, username string, name string, id int32, event WebhookEvent) (user *User, err error) {
+	op := gqlclient.NewOperation("query trackerWebhookSampleByUser ($username: String!, $name: String!, $id: Int!, $event: WebhookEvent!) {\n\tuser(username: $username) {\n\t\ttracker(name: $name) {\n\t\t\twebhook(id: $id) {\n\t\t\t\tsample(event: $event)\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func TicketWebhookSample(client *gqlclient.Client, ctx context.Context, name string, ticketId int32, id int32, event WebhookEvent) (me *User, err error) {
+	op := gqlclient.NewOperation("query ticketWebhookSample ($name: String!, $ticketId: Int!, $id: Int!, $event: WebhookEvent!) {\n\tme {\n\t\ttracker(name: $name) {\n\t\t\tticket(id: $ticketId) {\n\t\t\t\twebhook(id: $id) {\n\t\t\t\t\tsample(event: $event)\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("name", name)
+	op.Var("ticketId", ticketId)
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		Me *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.Me, err
+}
+
+func TicketWebhookSampleByUser(client *gqlclient.Client, ctx context.Context, username string, name string, ticketId int32, id int32, event WebhookEvent) (user *User, err error) {
+	op := gqlclient.NewOperation("query ticketWebhookSampleByUser ($username: String!, $name: String!, $ticketId: Int!, $id: Int!, $event: WebhookEvent!) {\n\tuser(username: $username) {\n\t\ttracker(name: $name) {\n\t\t\tticket(id: $ticketId) {\n\t\t\t\twebhook(id: $id) {\n\t\t\t\t\tsample(event: $event)\n\t\t\t\t}\n\t\t\t}\n\t\t}\n\t}\n}\n")
+	op.Var("username", username)
+	op.Var("name", name)
+	op.Var("ticketId", ticketId)
+	op.Var("id", id)
+	op.Var("event", event)
+	var respData struct {
+		User *User
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.User, err
+}
+
+func UpdateUserACL(client *gqlclient.Client, ctx context.Context, trackerId int32, userId int32, input ACLInput) (updateUserACL *TrackerACL, err error) {
+	op := gqlclient.NewOperation("mutation updateUserACL ($trackerId: Int!, $userId: Int!, $input: ACLInput!) {\n\tupdateUserACL(trackerId: $trackerId, userId: $userId, input: $input) {\n\t\tid\n\t}\n}\n")
+	op.Var("trackerId", trackerId)
+	op.Var("userId", userId)
+	op.Var("input", input)
+	var respData struct {
+		UpdateUserACL *TrackerACL
+	}
+	err = client.Execute(ctx, op, &respData)
+	return respData.UpdateUserACL, err
+}
@@ -0,0 +1,8 @@
+package todosrht
+
+import _ "embed"
+
+// Schema is the GraphQL schema of todo.sr.ht.
+//
+//go:embed schema.graphqls
+var Schema string
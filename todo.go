@@ -887,7 +887,7 @@ func newTodoTicketDeleteCommand() *cobra.Command {
 }
 
 func newTodoTicketShowCommand() *cobra.Command {
-	var web bool
+	var web, commentsOnly bool
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 
@@ -968,19 +968,51 @@ func newTodoTicketShowCommand() *cobra.Command {
 			fmt.Println(*ticket.Body)
 		}
 
-		for i := len(ticket.Events.Results) - 1; i >= 0; i-- {
-			event := ticket.Events.Results[i]
+		events := ticket.Events.Results
+		for cursor := ticket.Events.Cursor; cursor != nil; {
+			if owner != "" {
+				user, err = todosrht.TicketEventsByUser(c.Client, ctx, username, name, ticketID, cursor)
+			} else {
+				user, err = todosrht.TicketEventsByName(c.Client, ctx, name, ticketID, cursor)
+			}
+			if err != nil {
+				log.Fatal(err)
+			} else if user == nil || user.Tracker == nil || user.Tracker.Ticket == nil {
+				log.Fatalf("failed to fetch events of ticket %d", ticketID)
+			}
+
+			events = append(events, user.Tracker.Ticket.Events.Results...)
+			cursor = user.Tracker.Ticket.Events.Cursor
+		}
+
+		// Events are listed from newest to oldest
+		separate := true
+		for i := len(events) - 1; i >= 0; i-- {
+			event := events[i]
+			created := termfmt.Dim.String("(" + humanize.Time(event.Created.Time) + ")")
 			for _, change := range event.Changes {
-				comment, ok := change.Value.(*todosrht.Comment)
-				if !ok {
+				if comment, ok := change.Value.(*todosrht.Comment); ok {
+					author := termfmt.Bold.String(comment.Author.CanonicalName)
+					fmt.Println()
+					fmt.Printf("%v %v\n", author, created)
+					fmt.Print(indent(comment.Text, "  "))
+					fmt.Println()
+					separate = true
+					continue
+				}
+
+				if commentsOnly {
 					continue
 				}
-				author := termfmt.Bold.String(comment.Author.CanonicalName)
-				created := termfmt.Dim.String("(" + humanize.Time(event.Created.Time) + ")")
-				fmt.Println()
-				fmt.Printf("%v %v\n", author, created)
-				fmt.Print(indent(comment.Text, "  "))
-				fmt.Println()
+				s := formatTicketEvent(&change, ticket.Ref)
+				if s == "" {
+					continue
+				}
+				if separate {
+					fmt.Println()
+					separate = false
+				}
+				fmt.Printf("%s %s\n", s, created)
 			}
 		}
 	}
@@ -992,10 +1024,50 @@ func newTodoTicketShowCommand() *cobra.Command {
 		Run:               run,
 	}
 	cmd.Flags().BoolVar(&web, "web", false, "open in browser")
+	cmd.Flags().BoolVar(&commentsOnly, "comments-only", false, "only show comments")
 
 	return cmd
 }
 
+// formatTicketEvent formats a non-comment ticket event on a single line. ref
+// is the reference of the ticket the event belongs to.
+func formatTicketEvent(change *todosrht.EventDetail, ref string) string {
+	switch v := change.Value.(type) {
+	case *todosrht.StatusChange:
+		return fmt.Sprintf("%s changed status %s → %s", termfmt.Bold.String(v.Editor.CanonicalName),
+			formatTicketStatus(v.OldStatus, v.OldResolution), formatTicketStatus(v.NewStatus, v.NewResolution))
+	case *todosrht.LabelUpdate:
+		verb := "added"
+		if change.EventType == todosrht.EventTypeLabelRemoved {
+			verb = "removed"
+		}
+		return fmt.Sprintf("%s %s label %s", termfmt.Bold.String(v.Labeler.CanonicalName), verb, v.Label.TermString())
+	case *todosrht.Assignment:
+		verb := "assigned"
+		if change.EventType == todosrht.EventTypeUnassignedUser {
+			verb = "unassigned"
+		}
+		return fmt.Sprintf("%s %s %s", termfmt.Bold.String(v.Assigner.CanonicalName), verb, v.Assignee.CanonicalName)
+	case *todosrht.UserMention:
+		return fmt.Sprintf("%s mentioned %s", termfmt.Bold.String(v.Author.CanonicalName), v.Mentioned.CanonicalName)
+	case *todosrht.TicketMention:
+		if v.Mentioned.Ref == ref && change.Ticket != nil {
+			return fmt.Sprintf("%s mentioned this ticket in %s", termfmt.Bold.String(v.Author.CanonicalName), change.Ticket.Ref)
+		}
+		return fmt.Sprintf("%s mentioned %s", termfmt.Bold.String(v.Author.CanonicalName), v.Mentioned.Ref)
+	default:
+		// The submission of the ticket is already shown in the header
+		return ""
+	}
+}
+
+func formatTicketStatus(status todosrht.TicketStatus, resolution todosrht.TicketResolution) string {
+	if status != todosrht.TicketStatusResolved {
+		return string(status)
+	}
+	return fmt.Sprintf("%s (%s)", status, strings.ReplaceAll(strings.ToLower(string(resolution)), "_", " "))
+}
+
 func newTodoTicketWebhookCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "webhook",
@@ -1004,12 +1076,13 @@ func newTodoTicketWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newTodoTicketWebhookCreateCommand())
 	cmd.AddCommand(newTodoTicketWebhookListCommand())
 	cmd.AddCommand(newTodoTicketWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadTodoTicketWebhookDeliveries, loadTodoTicketWebhookSample, setupTodoTicketWebhookDeliveries)
 	return cmd
 }
 
 func newTodoTicketWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
@@ -1034,6 +1107,17 @@ func newTodoTicketWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("todo", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("todo", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
 
 		webhook, err := todosrht.CreateTicketWebhook(c.Client, ctx, trackerID, ticketID, config)
 		if err != nil {
@@ -1059,7 +1143,10 @@ func newTodoTicketWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -1154,6 +1241,100 @@ func newTodoTicketWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadTodoTicketWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	ticket, err := cmd.Flags().GetString("ticket")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ticketID, name, owner, instance, err := parseTicketResource(cmd.Context(), cmd, ticket)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := createClientWithInstance("todo", cmd, instance)
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		var (
+			user *todosrht.User
+			err  error
+		)
+		if username != "" {
+			user, err = todosrht.TicketWebhookDeliveriesByUser(c.Client, ctx, username, name, ticketID, id, (*todosrht.Cursor)(cursor))
+		} else {
+			user, err = todosrht.TicketWebhookDeliveries(c.Client, ctx, name, ticketID, id, (*todosrht.Cursor)(cursor))
+		}
+
+		if err != nil {
+			return nil, nil, err
+		} else if user == nil {
+			return nil, nil, fmt.Errorf("no such user %q", username)
+		} else if user.Tracker == nil {
+			return nil, nil, fmt.Errorf("no such tracker %q", name)
+		} else if user.Tracker.Ticket == nil {
+			return nil, nil, fmt.Errorf("no such ticket %d", ticketID)
+		} else if user.Tracker.Ticket.Webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertTodoWebhookDeliveries(user.Tracker.Ticket.Webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadTodoTicketWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	ticket, err := cmd.Flags().GetString("ticket")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ticketID, name, owner, instance, err := parseTicketResource(cmd.Context(), cmd, ticket)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := createClientWithInstance("todo", cmd, instance)
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		var (
+			user *todosrht.User
+			err  error
+		)
+		if username != "" {
+			user, err = todosrht.TicketWebhookSampleByUser(c.Client, ctx, username, name, ticketID, id, todosrht.WebhookEvent(event))
+		} else {
+			user, err = todosrht.TicketWebhookSample(c.Client, ctx, name, ticketID, id, todosrht.WebhookEvent(event))
+		}
+
+		if err != nil {
+			return "", err
+		} else if user == nil {
+			return "", fmt.Errorf("no such user %q", username)
+		} else if user.Tracker == nil {
+			return "", fmt.Errorf("no such tracker %q", name)
+		} else if user.Tracker.Ticket == nil {
+			return "", fmt.Errorf("no such ticket %d", ticketID)
+		} else if user.Tracker.Ticket.Webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return user.Tracker.Ticket.Webhook.Sample, nil
+	}
+}
+
+func setupTodoTicketWebhookDeliveries(cmd *cobra.Command) {
+	cmd.Flags().String("ticket", "", "ticket ID")
+	cmd.RegisterFlagCompletionFunc("ticket", completeTicketID)
+	cmd.MarkFlagRequired("ticket")
+}
+
 func newTodoLabelCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "label",
@@ -1360,6 +1541,7 @@ func newTodoACLCommand() *cobra.Command {
 	}
 	cmd.AddCommand(newTodoACLListCommand())
 	cmd.AddCommand(newTodoACLDeleteCommand())
+	cmd.AddCommand(newTodoACLApplyCommand())
 	return cmd
 }
 
@@ -1482,6 +1664,141 @@ func newTodoACLDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func newTodoACLApplyCommand() *cobra.Command {
+	var filename string
+	var prune, dryRun bool
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		resources, err := readACLFile(filename, "tracker", aclSetPermissions("browse", "submit", "comment", "edit", "triage"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, resource := range resources {
+			var name, owner, instance string
+			if resource.Name != "" {
+				name, owner, instance = parseResourceName(resource.Name)
+			} else if len(args) > 0 {
+				name, owner, instance = parseResourceName(args[0])
+			} else {
+				name, owner, instance, err = getTrackerName(ctx, cmd)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			c := createClientWithInstance("todo", cmd, instance)
+			backend := newTodoACLBackend(c, name, owner)
+			if err := applyACL(ctx, formatResourceName(name, owner), backend, resource.Entries, prune, dryRun); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "apply [tracker]",
+		Short:             "Apply ACL entries from a file",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTracker,
+		Run:               run,
+	}
+	cmd.Flags().StringVarP(&filename, "file", "f", "", "ACL file")
+	cmd.MarkFlagRequired("file")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete entries not listed in the file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the changes")
+	return cmd
+}
+
+func newTodoACLBackend(c *Client, name, owner string) *aclBackend {
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	var trackerID int32
+	return &aclBackend{
+		List: func(ctx context.Context) ([]aclEntry, error) {
+			var (
+				entries []aclEntry
+				cursor  *todosrht.Cursor
+				user    *todosrht.User
+				err     error
+			)
+			for {
+				if username != "" {
+					user, err = todosrht.AclByUser(c.Client, ctx, username, name, cursor)
+				} else {
+					user, err = todosrht.AclByTrackerName(c.Client, ctx, name, cursor)
+				}
+
+				if err != nil {
+					return nil, err
+				} else if user == nil {
+					return nil, fmt.Errorf("no such user %q", username)
+				} else if user.Tracker == nil {
+					return nil, fmt.Errorf("no such tracker %q", name)
+				}
+
+				for _, acl := range user.Tracker.Acls.Results {
+					var permissions []string
+					if acl.Browse {
+						permissions = append(permissions, "browse")
+					}
+					if acl.Submit {
+						permissions = append(permissions, "submit")
+					}
+					if acl.Comment {
+						permissions = append(permissions, "comment")
+					}
+					if acl.Edit {
+						permissions = append(permissions, "edit")
+					}
+					if acl.Triage {
+						permissions = append(permissions, "triage")
+					}
+					entries = append(entries, aclEntry{ID: acl.Id, Entity: acl.Entity.CanonicalName, Permissions: permissions})
+				}
+
+				cursor = user.Tracker.Acls.Cursor
+				if cursor == nil {
+					return entries, nil
+				}
+			}
+		},
+		Update: func(ctx context.Context, entity string, permissions []string) error {
+			if trackerID == 0 {
+				id, err := getTrackerID(c, ctx, name, owner)
+				if err != nil {
+					return err
+				}
+				trackerID = id
+			}
+
+			user, err := todosrht.UserIDByName(c.Client, ctx, strings.TrimLeft(entity, ownerPrefixes))
+			if err != nil {
+				return err
+			} else if user == nil {
+				return fmt.Errorf("no such user %q", entity)
+			}
+
+			input := todosrht.ACLInput{
+				Browse:  sliceContains(permissions, "browse"),
+				Submit:  sliceContains(permissions, "submit"),
+				Comment: sliceContains(permissions, "comment"),
+				Edit:    sliceContains(permissions, "edit"),
+				Triage:  sliceContains(permissions, "triage"),
+			}
+			_, err = todosrht.UpdateUserACL(c.Client, ctx, trackerID, user.Id, input)
+			return err
+		},
+		Delete: func(ctx context.Context, id int32) error {
+			_, err := todosrht.DeleteACL(c.Client, ctx, id)
+			return err
+		},
+	}
+}
+
 func newTodoWebhookCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "webhook",
@@ -1490,12 +1807,13 @@ func newTodoWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newTodoWebhookCreateCommand())
 	cmd.AddCommand(newTodoWebhookListCommand())
 	cmd.AddCommand(newTodoWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadTodoWebhookDeliveries, loadTodoWebhookSample, nil)
 	return cmd
 }
 
 func newTodoWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
@@ -1526,6 +1844,17 @@ func newTodoWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("todo", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("todo", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
 
 		webhook, err := todosrht.CreateTrackerWebhook(c.Client, ctx, id, config)
 		if err != nil {
@@ -1551,7 +1880,10 @@ func newTodoWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -1655,6 +1987,80 @@ func newTodoWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadTodoWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	name, owner, instance, err := getTrackerName(cmd.Context(), cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := createClientWithInstance("todo", cmd, instance)
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		var (
+			user *todosrht.User
+			err  error
+		)
+		if username != "" {
+			user, err = todosrht.TrackerWebhookDeliveriesByUser(c.Client, ctx, username, name, id, (*todosrht.Cursor)(cursor))
+		} else {
+			user, err = todosrht.TrackerWebhookDeliveries(c.Client, ctx, name, id, (*todosrht.Cursor)(cursor))
+		}
+
+		if err != nil {
+			return nil, nil, err
+		} else if user == nil {
+			return nil, nil, fmt.Errorf("no such user %q", username)
+		} else if user.Tracker == nil {
+			return nil, nil, fmt.Errorf("no such tracker %q", name)
+		} else if user.Tracker.Webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertTodoWebhookDeliveries(user.Tracker.Webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadTodoWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	name, owner, instance, err := getTrackerName(cmd.Context(), cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c := createClientWithInstance("todo", cmd, instance)
+	var username string
+	if owner != "" {
+		username = strings.TrimLeft(owner, ownerPrefixes)
+	}
+
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		var (
+			user *todosrht.User
+			err  error
+		)
+		if username != "" {
+			user, err = todosrht.TrackerWebhookSampleByUser(c.Client, ctx, username, name, id, todosrht.WebhookEvent(event))
+		} else {
+			user, err = todosrht.TrackerWebhookSample(c.Client, ctx, name, id, todosrht.WebhookEvent(event))
+		}
+
+		if err != nil {
+			return "", err
+		} else if user == nil {
+			return "", fmt.Errorf("no such user %q", username)
+		} else if user.Tracker == nil {
+			return "", fmt.Errorf("no such tracker %q", name)
+		} else if user.Tracker.Webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return user.Tracker.Webhook.Sample, nil
+	}
+}
+
 func newTodoUserWebhookCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "user-webhook",
@@ -1663,16 +2069,16 @@ func newTodoUserWebhookCommand() *cobra.Command {
 	cmd.AddCommand(newTodoUserWebhookCreateCommand())
 	cmd.AddCommand(newTodoUserWebhookListCommand())
 	cmd.AddCommand(newTodoUserWebhookDeleteCommand())
+	addWebhookDeliveryCommands(cmd, loadTodoUserWebhookDeliveries, loadTodoUserWebhookSample, nil)
 	return cmd
 }
 
 func newTodoUserWebhookCreateCommand() *cobra.Command {
 	var events []string
-	var stdin bool
+	var stdin, sample, noValidate bool
 	var url, query string
 	run := func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		c := createClient("todo", cmd)
 
 		var config todosrht.UserWebhookInput
 		config.Url = url
@@ -1683,6 +2089,19 @@ func newTodoUserWebhookCreateCommand() *cobra.Command {
 		}
 		config.Events = whEvents
 		config.Query = readWebhookQuery(stdin, query)
+		if sample {
+			if err := printWebhookSamples("todo", config.Query, events); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+		if !noValidate {
+			if err := checkWebhookQuery("todo", config.Query); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		c := createClient("todo", cmd)
 
 		webhook, err := todosrht.CreateUserWebhook(c.Client, ctx, config)
 		if err != nil {
@@ -1708,7 +2127,10 @@ func newTodoUserWebhookCreateCommand() *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive("query", "stdin")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "payload url")
 	cmd.RegisterFlagCompletionFunc("url", cobra.NoFileCompletions)
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolVar(&sample, "sample", false, "print sample payloads instead of creating the webhook")
+	cmd.Flags().BoolVar(&noValidate, "no-validate", false, "do not validate the webhook query against the bundled schema")
+	cmd.MarkFlagsOneRequired("url", "sample")
+	cmd.MarkFlagsMutuallyExclusive("url", "sample")
 	return cmd
 }
 
@@ -1780,6 +2202,50 @@ func newTodoUserWebhookDeleteCommand() *cobra.Command {
 	return cmd
 }
 
+func loadTodoUserWebhookDeliveries(cmd *cobra.Command) webhookDeliveriesFunc {
+	c := createClient("todo", cmd)
+	return func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error) {
+		webhook, err := todosrht.UserWebhookDeliveries(c.Client, ctx, id, (*todosrht.Cursor)(cursor))
+		if err != nil {
+			return nil, nil, err
+		} else if webhook == nil {
+			return nil, nil, fmt.Errorf("no such webhook %d", id)
+		}
+
+		deliveries, next := convertTodoWebhookDeliveries(webhook.Deliveries)
+		return deliveries, next, nil
+	}
+}
+
+func loadTodoUserWebhookSample(cmd *cobra.Command) webhookSampleFunc {
+	c := createClient("todo", cmd)
+	return func(ctx context.Context, id int32, event string) (string, error) {
+		webhook, err := todosrht.UserWebhookSample(c.Client, ctx, id, todosrht.WebhookEvent(event))
+		if err != nil {
+			return "", err
+		} else if webhook == nil {
+			return "", fmt.Errorf("no such webhook %d", id)
+		}
+		return webhook.Sample, nil
+	}
+}
+
+func convertTodoWebhookDeliveries(cursor *todosrht.WebhookDeliveryCursor) ([]webhookDelivery, *string) {
+	var deliveries []webhookDelivery
+	for _, delivery := range cursor.Results {
+		deliveries = append(deliveries, webhookDelivery{
+			UUID:            delivery.Uuid,
+			Date:            delivery.Date.Time,
+			Event:           string(delivery.Event),
+			RequestBody:     delivery.RequestBody,
+			ResponseBody:    delivery.ResponseBody,
+			ResponseHeaders: delivery.ResponseHeaders,
+			ResponseStatus:  delivery.ResponseStatus,
+		})
+	}
+	return deliveries, (*string)(cursor.Cursor)
+}
+
 const todoTicketCreatePrefill = `
 <!--
 Please enter the subject of the new ticket above. The subject line
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"testing"
+
+	"git.sr.ht/~xenrox/hut/srht/todosrht"
+)
+
+func TestFormatTicketEvent(t *testing.T) {
+	alice := &todosrht.Entity{CanonicalName: "~alice"}
+	bob := &todosrht.Entity{CanonicalName: "~bob"}
+	ticket := &todosrht.Ticket{Ref: "~alice/foo#1"}
+	other := &todosrht.Ticket{Ref: "~alice/foo#2"}
+
+	tests := []struct {
+		name   string
+		change todosrht.EventDetail
+		want   string
+	}{
+		{
+			name: "status",
+			change: todosrht.EventDetail{EventType: todosrht.EventTypeStatusChange, Value: &todosrht.StatusChange{
+				Editor:        alice,
+				OldStatus:     todosrht.TicketStatusReported,
+				NewStatus:     todosrht.TicketStatusResolved,
+				NewResolution: todosrht.TicketResolutionWontFix,
+			}},
+			want: "~alice changed status REPORTED → RESOLVED (wont fix)",
+		},
+		{
+			name: "label added",
+			change: todosrht.EventDetail{EventType: todosrht.EventTypeLabelAdded, Value: &todosrht.LabelUpdate{
+				Labeler: alice,
+				Label:   &todosrht.Label{Name: "bug"},
+			}},
+			want: "~alice added label  bug ",
+		},
+		{
+			name: "label removed",
+			change: todosrht.EventDetail{EventType: todosrht.EventTypeLabelRemoved, Value: &todosrht.LabelUpdate{
+				Labeler: alice,
+				Label:   &todosrht.Label{Name: "bug"},
+			}},
+			want: "~alice removed label  bug ",
+		},
+		{
+			name: "assigned",
+			change: todosrht.EventDetail{EventType: todosrht.EventTypeAssignedUser, Value: &todosrht.Assignment{
+				Assigner: alice,
+				Assignee: bob,
+			}},
+			want: "~alice assigned ~bob",
+		},
+		{
+			name: "unassigned",
+			change: todosrht.EventDetail{EventType: todosrht.EventTypeUnassignedUser, Value: &todosrht.Assignment{
+				Assigner: alice,
+				Assignee: bob,
+			}},
+			want: "~alice unassigned ~bob",
+		},
+		{
+			name: "user mention",
+			change: todosrht.EventDetail{EventType: todosrht.EventTypeUserMentioned, Value: &todosrht.UserMention{
+				Author:    alice,
+				Mentioned: bob,
+			}},
+			want: "~alice mentioned ~bob",
+		},
+		{
+			name: "mentioned in other ticket",
+			change: todosrht.EventDetail{EventType: todosrht.EventTypeTicketMentioned, Ticket: other, Value: &todosrht.TicketMention{
+				Author:    alice,
+				Mentioned: ticket,
+			}},
+			want: "~alice mentioned this ticket in ~alice/foo#2",
+		},
+		{
+			name: "mentioning other ticket",
+			change: todosrht.EventDetail{EventType: todosrht.EventTypeTicketMentioned, Ticket: ticket, Value: &todosrht.TicketMention{
+				Author:    alice,
+				Mentioned: other,
+			}},
+			want: "~alice mentioned ~alice/foo#2",
+		},
+		{
+			name:   "created",
+			change: todosrht.EventDetail{EventType: todosrht.EventTypeCreated, Value: &todosrht.Created{Author: alice}},
+			want:   "",
+		},
+	}
+
+	for _, test := range tests {
+		got := formatTicketEvent(&test.change, ticket.Ref)
+		if got != test.want {
+			t.Errorf("formatTicketEvent(%s): expected %q, got %q", test.name, test.want, got)
+		}
+	}
+}
+
+func TestFormatTicketStatus(t *testing.T) {
+	tests := []struct {
+		status     todosrht.TicketStatus
+		resolution todosrht.TicketResolution
+		want       string
+	}{
+		{todosrht.TicketStatusReported, todosrht.TicketResolutionUnresolved, "REPORTED"},
+		{todosrht.TicketStatusResolved, todosrht.TicketResolutionFixed, "RESOLVED (fixed)"},
+		{todosrht.TicketStatusResolved, todosrht.TicketResolutionNotOurBug, "RESOLVED (not our bug)"},
+	}
+
+	for _, test := range tests {
+		got := formatTicketStatus(test.status, test.resolution)
+		if got != test.want {
+			t.Errorf("formatTicketStatus(%q, %q): expected %q, got %q", test.status, test.resolution, test.want, got)
+		}
+	}
+}
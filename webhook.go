@@ -0,0 +1,503 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/shlex"
+	"github.com/spf13/cobra"
+
+	"git.sr.ht/~xenrox/hut/termfmt"
+)
+
+// webhookDelivery is a webhook delivery, independent of the sr.ht service it
+// was sent by.
+type webhookDelivery struct {
+	UUID            string
+	Date            time.Time
+	Event           string
+	RequestBody     string
+	ResponseBody    *string
+	ResponseHeaders *string
+	ResponseStatus  *int32
+}
+
+// webhookDeliveriesFunc fetches a page of deliveries of the webhook with the
+// given ID. The returned cursor is nil if there are no more deliveries.
+type webhookDeliveriesFunc func(ctx context.Context, id int32, cursor *string) ([]webhookDelivery, *string, error)
+
+// webhookDeliveriesLoader returns a webhookDeliveriesFunc for a command. This
+// is where the client is created and where the resource the webhook belongs
+// to is resolved.
+type webhookDeliveriesLoader func(cmd *cobra.Command) webhookDeliveriesFunc
+
+// webhookSampleFunc fetches a sample payload of the webhook with the given ID
+// for an event.
+type webhookSampleFunc func(ctx context.Context, id int32, event string) (string, error)
+
+// webhookSampleLoader returns a webhookSampleFunc for a command.
+type webhookSampleLoader func(cmd *cobra.Command) webhookSampleFunc
+
+// addWebhookDeliveryCommands adds the commands to inspect and replay webhook
+// deliveries and to fetch sample payloads to a webhook command. setup is
+// called on each added command and can be used to register flags required by
+// the loaders.
+func addWebhookDeliveryCommands(cmd *cobra.Command, load webhookDeliveriesLoader, loadSample webhookSampleLoader, setup func(cmd *cobra.Command)) {
+	commands := []*cobra.Command{
+		newWebhookDeliveriesCommand(load),
+		newWebhookDeliveryShowCommand(load),
+		newWebhookReplayCommand(load),
+		newWebhookSampleCommand(loadSample),
+	}
+
+	delivery := &cobra.Command{
+		Use:   "delivery",
+		Short: "Inspect webhook deliveries",
+	}
+	delivery.AddCommand(commands[1])
+
+	cmd.AddCommand(commands[0], delivery, commands[2], commands[3])
+
+	if setup != nil {
+		for _, c := range commands {
+			setup(c)
+		}
+	}
+}
+
+func newWebhookDeliveriesCommand(load webhookDeliveriesLoader) *cobra.Command {
+	var count int
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		id, err := parseInt32(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fetch := load(cmd)
+		var cursor *string
+		err = pagerify(func(p pager) error {
+			deliveries, next, err := fetch(ctx, id, cursor)
+			if err != nil {
+				return err
+			}
+
+			for _, delivery := range deliveries {
+				printWebhookDelivery(p, &delivery)
+			}
+
+			cursor = next
+			if p.IsDone(cursor, len(deliveries)) {
+				return pagerDone
+			}
+
+			return nil
+		}, count)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "deliveries <ID>",
+		Short:             "List webhook deliveries",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().IntVar(&count, "count", 0, "number of deliveries to fetch")
+	cmd.RegisterFlagCompletionFunc("count", cobra.NoFileCompletions)
+	return cmd
+}
+
+func newWebhookDeliveryShowCommand(load webhookDeliveriesLoader) *cobra.Command {
+	var webhookID int32
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		delivery, err := findWebhookDelivery(ctx, load(cmd), webhookID, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = pagerify(func(p pager) error {
+			printWebhookDelivery(p, delivery)
+
+			fmt.Fprintf(p, "\n%s\n", termfmt.Bold.String("Request body:"))
+			fmt.Fprintln(p, formatWebhookPayload(delivery.RequestBody))
+
+			if delivery.ResponseHeaders != nil {
+				fmt.Fprintf(p, "\n%s\n", termfmt.Bold.String("Response headers:"))
+				fmt.Fprintln(p, strings.TrimSpace(*delivery.ResponseHeaders))
+			}
+			if delivery.ResponseBody != nil {
+				fmt.Fprintf(p, "\n%s\n", termfmt.Bold.String("Response body:"))
+				fmt.Fprintln(p, strings.TrimSpace(*delivery.ResponseBody))
+			}
+
+			return pagerDone
+		}, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "show <uuid>",
+		Short:             "Show a webhook delivery",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().Int32VarP(&webhookID, "webhook", "w", 0, "webhook ID")
+	cmd.RegisterFlagCompletionFunc("webhook", cobra.NoFileCompletions)
+	cmd.MarkFlagRequired("webhook")
+	return cmd
+}
+
+func newWebhookReplayCommand(load webhookDeliveriesLoader) *cobra.Command {
+	var webhookID int32
+	var to string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		delivery, err := findWebhookDelivery(ctx, load(cmd), webhookID, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		header := make(http.Header)
+		header.Set("X-Webhook-Event", delivery.Event)
+		header.Set("X-Webhook-Delivery", delivery.UUID)
+
+		status, err := postWebhookPayload(ctx, to, header, []byte(delivery.RequestBody))
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Replayed delivery %s: %s\n", delivery.UUID, status)
+	}
+
+	cmd := &cobra.Command{
+		Use:               "replay <uuid>",
+		Short:             "Replay a webhook delivery to another URL",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().Int32VarP(&webhookID, "webhook", "w", 0, "webhook ID")
+	cmd.RegisterFlagCompletionFunc("webhook", cobra.NoFileCompletions)
+	cmd.MarkFlagRequired("webhook")
+	cmd.Flags().StringVar(&to, "to", "", "URL to send the payload to")
+	cmd.RegisterFlagCompletionFunc("to", cobra.NoFileCompletions)
+	cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+func newWebhookSampleCommand(load webhookSampleLoader) *cobra.Command {
+	var event, to string
+	run := func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		id, err := parseInt32(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		event = strings.ToUpper(event)
+		payload, err := load(cmd)(ctx, id, event)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if to == "" {
+			fmt.Println(formatWebhookPayload(payload))
+			return
+		}
+
+		header := make(http.Header)
+		header.Set("X-Webhook-Event", event)
+
+		status, err := postWebhookPayload(ctx, to, header, []byte(payload))
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Sent sample %s payload: %s\n", strings.ToLower(event), status)
+	}
+
+	cmd := &cobra.Command{
+		Use:               "sample <ID>",
+		Short:             "Fetch a sample webhook payload",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().StringVarP(&event, "event", "e", "", "webhook event")
+	cmd.RegisterFlagCompletionFunc("event", cobra.NoFileCompletions)
+	cmd.MarkFlagRequired("event")
+	cmd.Flags().StringVar(&to, "to", "", "URL to send the payload to")
+	cmd.RegisterFlagCompletionFunc("to", cobra.NoFileCompletions)
+	return cmd
+}
+
+func findWebhookDelivery(ctx context.Context, fetch webhookDeliveriesFunc, id int32, uuid string) (*webhookDelivery, error) {
+	var cursor *string
+	for {
+		deliveries, next, err := fetch(ctx, id, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, delivery := range deliveries {
+			if delivery.UUID == uuid {
+				return &delivery, nil
+			}
+		}
+
+		if next == nil {
+			return nil, fmt.Errorf("no delivery %q for webhook %d", uuid, id)
+		}
+		cursor = next
+	}
+}
+
+func printWebhookDelivery(w io.Writer, delivery *webhookDelivery) {
+	status := termfmt.Dim.String("pending")
+	if delivery.ResponseStatus != nil {
+		code := int(*delivery.ResponseStatus)
+		status = fmt.Sprintf("%d %s", code, http.StatusText(code))
+		if code >= 200 && code < 300 {
+			status = termfmt.Green.String(status)
+		} else {
+			status = termfmt.Red.String(status)
+		}
+	}
+
+	fmt.Fprintf(w, "%s %s %s %s\n", termfmt.DarkYellow.String(delivery.UUID),
+		strings.ToLower(delivery.Event), status, termfmt.Dim.String(humanize.Time(delivery.Date)))
+}
+
+// postWebhookPayload sends a webhook payload to a URL with the provided
+// additional headers and returns the response status.
+func postWebhookPayload(ctx context.Context, url string, header http.Header, payload []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "hut/"+version)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("HTTP request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return "", fmt.Errorf("failed to read response body: %v", err)
+	}
+	return resp.Status, nil
+}
+
+// formatWebhookPayload indents a JSON payload, or returns it unchanged if it
+// is not valid JSON.
+func formatWebhookPayload(payload string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(payload), "", "  "); err != nil {
+		return payload
+	}
+	return buf.String()
+}
+
+// srhtWebhookPublicKey is the public key used by sr.ht to sign webhook
+// payloads.
+const srhtWebhookPublicKey = "uX7KWyyDNMaBma4aVbJ/cbUQpdjqczuCyK/HxzV/u+4="
+
+// maxWebhookPayloadSize is the maximum size of a webhook payload accepted by
+// the local webhook receiver.
+const maxWebhookPayloadSize = 10 << 20
+
+func newWebhookCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Develop webhook integrations",
+	}
+	cmd.AddCommand(newWebhookListenCommand())
+	return cmd
+}
+
+func newWebhookListenCommand() *cobra.Command {
+	var port int
+	var publicKey, forward, execCmd string
+	var noVerify bool
+	run := func(cmd *cobra.Command, args []string) {
+		receiver := &webhookReceiver{
+			forward: forward,
+			nonces:  make(map[string]struct{}),
+		}
+
+		if !noVerify {
+			key, err := base64.StdEncoding.DecodeString(publicKey)
+			if err != nil || len(key) != ed25519.PublicKeySize {
+				log.Fatalf("invalid public key %q", publicKey)
+			}
+			receiver.publicKey = ed25519.PublicKey(key)
+		}
+
+		if execCmd != "" {
+			var err error
+			receiver.exec, err = shlex.Split(execCmd)
+			if err != nil {
+				log.Fatalf("failed to parse command: %v", err)
+			} else if len(receiver.exec) == 0 {
+				log.Fatal("empty command")
+			}
+		}
+
+		addr := net.JoinHostPort("localhost", strconv.Itoa(port))
+		log.Printf("Listening for webhooks on http://%s\n", addr)
+		if err := http.ListenAndServe(addr, receiver); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:               "listen",
+		Short:             "Receive webhooks locally",
+		Args:              cobra.ExactArgs(0),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               run,
+	}
+	cmd.Flags().IntVarP(&port, "port", "p", 8080, "port to listen on")
+	cmd.RegisterFlagCompletionFunc("port", cobra.NoFileCompletions)
+	cmd.Flags().StringVar(&publicKey, "public-key", srhtWebhookPublicKey, "public key used to verify payload signatures")
+	cmd.RegisterFlagCompletionFunc("public-key", cobra.NoFileCompletions)
+	cmd.Flags().BoolVar(&noVerify, "no-verify", false, "accept payloads without valid signature")
+	cmd.MarkFlagsMutuallyExclusive("public-key", "no-verify")
+	cmd.Flags().StringVar(&forward, "forward", "", "URL to forward payloads to")
+	cmd.RegisterFlagCompletionFunc("forward", cobra.NoFileCompletions)
+	cmd.Flags().StringVar(&execCmd, "exec", "", "command to run for each payload")
+	cmd.RegisterFlagCompletionFunc("exec", cobra.NoFileCompletions)
+	return cmd
+}
+
+// webhookReceiver is an HTTP handler receiving webhook payloads sent by
+// sr.ht.
+type webhookReceiver struct {
+	publicKey ed25519.PublicKey // nil if signatures are not verified
+	forward   string
+	exec      []string
+
+	mu     sync.Mutex
+	nonces map[string]struct{}
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxWebhookPayloadSize))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+
+	// Handle one payload at a time, to keep the output readable
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event := req.Header.Get("X-Webhook-Event")
+	delivery := req.Header.Get("X-Webhook-Delivery")
+
+	if r.publicKey != nil {
+		if err := r.verify(req.Header, payload); err != nil {
+			log.Printf("Rejected payload %s: %v\n", delivery, err)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	fmt.Printf("%s %s %s\n", termfmt.DarkYellow.String(delivery),
+		strings.ToLower(event), termfmt.Dim.String(time.Now().Format(dateLayout)))
+	fmt.Println(formatWebhookPayload(string(payload)))
+	fmt.Println()
+
+	status := http.StatusOK
+	if r.forward != "" {
+		header := make(http.Header)
+		for _, k := range []string{"X-Webhook-Event", "X-Webhook-Delivery", "X-Payload-Signature", "X-Payload-Nonce"} {
+			if v := req.Header.Get(k); v != "" {
+				header.Set(k, v)
+			}
+		}
+
+		respStatus, err := postWebhookPayload(req.Context(), r.forward, header, payload)
+		if err != nil {
+			log.Printf("Failed to forward payload: %v\n", err)
+			status = http.StatusBadGateway
+		} else {
+			log.Printf("Forwarded payload: %s\n", respStatus)
+		}
+	}
+
+	if r.exec != nil {
+		cmd := exec.Command(r.exec[0], r.exec[1:]...)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(), "HUT_WEBHOOK_EVENT="+event, "HUT_WEBHOOK_DELIVERY="+delivery)
+		if err := cmd.Run(); err != nil {
+			log.Printf("Command failed: %v\n", err)
+			status = http.StatusInternalServerError
+		}
+	}
+
+	w.WriteHeader(status)
+}
+
+// verify checks the signature of a payload. sr.ht signs the payload
+// concatenated with a nonce, which must not be reused.
+func (r *webhookReceiver) verify(header http.Header, payload []byte) error {
+	signature, err := base64.StdEncoding.DecodeString(header.Get("X-Payload-Signature"))
+	if err != nil || len(signature) == 0 {
+		return errors.New("missing or invalid signature")
+	}
+
+	nonce := header.Get("X-Payload-Nonce")
+	if nonce == "" {
+		return errors.New("missing nonce")
+	}
+
+	msg := append(append([]byte(nil), payload...), nonce...)
+	if !ed25519.Verify(r.publicKey, msg, signature) {
+		return errors.New("invalid signature")
+	}
+
+	if _, ok := r.nonces[nonce]; ok {
+		return errors.New("nonce has already been used")
+	}
+	r.nonces[nonce] = struct{}{}
+
+	return nil
+}
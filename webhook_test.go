@@ -0,0 +1,125 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testWebhookPayload = `{"data":{"webhook":{"uuid":"0b3e1b1c-0000-0000-0000-000000000000","event":"JOB_CREATED","date":"2024-01-01T00:00:00Z","job":{"id":1,"status":"PENDING"}}}}`
+
+func TestWebhookReceiver(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(payload, nonce string) string {
+		return base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(payload+nonce)))
+	}
+
+	receiver := &webhookReceiver{publicKey: publicKey, nonces: make(map[string]struct{})}
+
+	tests := []struct {
+		name      string
+		method    string
+		payload   string
+		signature string
+		nonce     string
+		status    int
+	}{
+		{"valid", http.MethodPost, testWebhookPayload, sign(testWebhookPayload, "1"), "1", http.StatusOK},
+		{"replayed nonce", http.MethodPost, testWebhookPayload, sign(testWebhookPayload, "1"), "1", http.StatusForbidden},
+		{"tampered body", http.MethodPost, strings.Replace(testWebhookPayload, "PENDING", "SUCCESS", 1), sign(testWebhookPayload, "2"), "2", http.StatusForbidden},
+		{"tampered nonce", http.MethodPost, testWebhookPayload, sign(testWebhookPayload, "3"), "4", http.StatusForbidden},
+		{"bad signature", http.MethodPost, testWebhookPayload, base64.StdEncoding.EncodeToString([]byte("invalid")), "5", http.StatusForbidden},
+		{"malformed signature", http.MethodPost, testWebhookPayload, "not base64!", "6", http.StatusForbidden},
+		{"missing signature", http.MethodPost, testWebhookPayload, "", "7", http.StatusForbidden},
+		{"missing nonce", http.MethodPost, testWebhookPayload, sign(testWebhookPayload, ""), "", http.StatusForbidden},
+		{"wrong method", http.MethodGet, "", "", "", http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "/", strings.NewReader(test.payload))
+		req.Header.Set("X-Webhook-Event", "JOB_CREATED")
+		req.Header.Set("X-Webhook-Delivery", "0b3e1b1c-0000-0000-0000-000000000000")
+		if test.signature != "" {
+			req.Header.Set("X-Payload-Signature", test.signature)
+		}
+		if test.nonce != "" {
+			req.Header.Set("X-Payload-Nonce", test.nonce)
+		}
+
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, req)
+		if rec.Code != test.status {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.status, rec.Code)
+		}
+	}
+}
+
+func TestWebhookReceiverNoVerify(t *testing.T) {
+	receiver := &webhookReceiver{nonces: make(map[string]struct{})}
+
+	// Without a public key, unsigned and replayed payloads are accepted
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testWebhookPayload))
+		req.Header.Set("X-Payload-Nonce", "1")
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("request %d: expected status %d, got %d", i, http.StatusOK, rec.Code)
+		}
+	}
+}
+
+func TestWebhookReceiverExec(t *testing.T) {
+	tests := []struct {
+		exec   []string
+		status int
+	}{
+		{[]string{"true"}, http.StatusOK},
+		{[]string{"false"}, http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		receiver := &webhookReceiver{exec: test.exec, nonces: make(map[string]struct{})}
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testWebhookPayload))
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, req)
+		if rec.Code != test.status {
+			t.Errorf("exec %v: expected status %d, got %d", test.exec, test.status, rec.Code)
+		}
+	}
+}
+
+func TestWebhookReceiverForward(t *testing.T) {
+	var got string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = req.Header.Get("X-Webhook-Event")
+	}))
+	defer target.Close()
+
+	receiver := &webhookReceiver{forward: target.URL, nonces: make(map[string]struct{})}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testWebhookPayload))
+	req.Header.Set("X-Webhook-Event", "JOB_CREATED")
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if got != "JOB_CREATED" {
+		t.Errorf("forwarded event: expected %q, got %q", "JOB_CREATED", got)
+	}
+
+	receiver.forward = "http://127.0.0.1:1"
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testWebhookPayload))
+	rec = httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("unreachable target: expected status %d, got %d", http.StatusBadGateway, rec.Code)
+	}
+}